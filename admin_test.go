@@ -0,0 +1,105 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"backend/auth"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminUserManagement(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+	backend.SetKeys(backend.NewEd25519KeySet())
+
+	hasher := backend.NewBcryptHasher(4)
+	hash, err := hasher.Hash("tandem-velodrome-42")
+	assert.NoError(t, err)
+	prefix := fmt.Sprintf("admin-test-%d", time.Now().UnixNano())
+	user, err := prismaClient.User.CreateOne(
+		db.User.Email.Set(prefix+"-rider@example.com"),
+		db.User.Password.Set(hash),
+		db.User.Surname.Set("Doe"),
+		db.User.Age.Set(30),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.User.FindMany(db.User.Email.StartsWith(prefix)).Delete().Exec(ctx)
+
+	server := &backend.AdminServer{PrismaClient: prismaClient}
+	authService := &backend.AuthenticatorServer{PrismaClient: prismaClient, Hasher: hasher}
+	adminCtx := auth.NewContext(ctx, &auth.Identity{Email: prefix + "-admin@example.com", Role: backend.RoleAdmin, MFA: true})
+	login := &pb.LoginRequest{Email: user.Email, Password: "tandem-velodrome-42"}
+	session, err := authService.Login(ctx, login)
+	assert.NoError(t, err)
+
+	listed, err := server.ListUsers(adminCtx, &pb.ListUsersRequest{EmailPrefix: prefix, Role: backend.RoleRider})
+	assert.NoError(t, err)
+	assert.Len(t, listed.Users, 1)
+
+	// Act
+	disabled, err := server.DisableUser(adminCtx, &pb.DisableUserRequest{Id: int32(user.ID)})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, disabled.Disabled)
+	_, err = authService.Login(ctx, login)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = authService.Refresh(ctx, &pb.RefreshRequest{RefreshToken: session.RefreshToken})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = server.EnableUser(adminCtx, &pb.EnableUserRequest{Id: int32(user.ID)})
+	assert.NoError(t, err)
+	_, err = authService.Login(ctx, login)
+	assert.NoError(t, err)
+
+	promoted, err := server.SetRole(adminCtx, &pb.SetRoleRequest{Id: int32(user.ID), Role: backend.RoleOperator})
+	assert.NoError(t, err)
+	assert.Equal(t, backend.RoleOperator, promoted.Role)
+	_, err = server.SetRole(adminCtx, &pb.SetRoleRequest{Id: int32(user.ID), Role: "owner"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	details, err := server.GetUser(adminCtx, &pb.GetUserRequest{Id: int32(user.ID)})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), details.RentalCount)
+}
+
+func TestAdminCannotDisableThemselves(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	admin, err := prismaClient.User.CreateOne(
+		db.User.Email.Set(fmt.Sprintf("admin-self-%d@example.com", time.Now().UnixNano())),
+		db.User.Password.Set("unused"),
+		db.User.Surname.Set("Doe"),
+		db.User.Age.Set(30),
+		db.User.Role.Set(backend.RoleAdmin),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.User.FindUnique(db.User.ID.Equals(admin.ID)).Delete().Exec(ctx)
+
+	server := &backend.AdminServer{PrismaClient: prismaClient}
+	adminCtx := auth.NewContext(ctx, &auth.Identity{Email: admin.Email, Role: backend.RoleAdmin, MFA: true})
+
+	// Act
+	_, disableErr := server.DisableUser(adminCtx, &pb.DisableUserRequest{Id: int32(admin.ID)})
+	_, demoteErr := server.SetRole(adminCtx, &pb.SetRoleRequest{Id: int32(admin.ID), Role: backend.RoleRider})
+
+	// Assert
+	assert.Equal(t, codes.FailedPrecondition, status.Code(disableErr))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(demoteErr))
+}
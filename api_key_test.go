@@ -0,0 +1,118 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"backend/auth"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestApiKeyLifecycle(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	user, err := prismaClient.User.CreateOne(
+		db.User.Email.Set(fmt.Sprintf("apikey-test-%d@example.com", time.Now().UnixNano())),
+		db.User.Password.Set("unused"),
+		db.User.Surname.Set("Doe"),
+		db.User.Age.Set(30),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.User.FindUnique(db.User.ID.Equals(user.ID)).Delete().Exec(ctx)
+
+	server := &backend.ApiKeyServer{PrismaClient: prismaClient}
+	store := &backend.APIKeyStore{PrismaClient: prismaClient}
+	userCtx := auth.NewContext(ctx, &auth.Identity{Email: user.Email, Role: backend.RoleRider})
+
+	// Act
+	created, err := server.CreateApiKey(userCtx, &pb.CreateApiKeyRequest{
+		Name:   "reporting",
+		Scopes: []string{backend.ScopeRentalsRead},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Key, created.ApiKey.Prefix))
+	stored, err := prismaClient.APIKey.FindUnique(db.APIKey.ID.Equals(int(created.ApiKey.Id))).Exec(ctx)
+	assert.NoError(t, err)
+	assert.NotContains(t, stored.KeyHash, created.Key)
+
+	identity, err := store.Authenticate(ctx, created.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, user.Email, identity.Email)
+	assert.Equal(t, []string{backend.ScopeRentalsRead}, identity.Scopes)
+
+	listed, err := server.ListApiKeys(userCtx, &pb.ListApiKeysRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, listed.ApiKeys, 1)
+
+	// riders cannot mint service account keys
+	_, err = server.CreateApiKey(userCtx, &pb.CreateApiKeyRequest{
+		Name:           "dock",
+		Scopes:         []string{backend.ScopeBikesWrite},
+		ServiceAccount: true,
+		Role:           backend.RoleOperator,
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = server.RevokeApiKey(userCtx, &pb.RevokeApiKeyRequest{Id: created.ApiKey.Id})
+	assert.NoError(t, err)
+	_, err = store.Authenticate(ctx, created.Key)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestApiKeyRoleIsCappedAtCreation(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	user, err := prismaClient.User.CreateOne(
+		db.User.Email.Set(fmt.Sprintf("apikey-role-%d@example.com", time.Now().UnixNano())),
+		db.User.Password.Set("unused"),
+		db.User.Surname.Set("Doe"),
+		db.User.Age.Set(30),
+		db.User.Role.Set(backend.RoleOperator),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.User.FindUnique(db.User.ID.Equals(user.ID)).Delete().Exec(ctx)
+
+	server := &backend.ApiKeyServer{PrismaClient: prismaClient}
+	store := &backend.APIKeyStore{PrismaClient: prismaClient}
+	operatorCtx := auth.NewContext(ctx, &auth.Identity{Email: user.Email, Role: backend.RoleOperator, MFA: true})
+	created, err := server.CreateApiKey(operatorCtx, &pb.CreateApiKeyRequest{
+		Name:   "dock",
+		Scopes: []string{backend.ScopeBikesWrite},
+	})
+	assert.NoError(t, err)
+	setRole := func(role string) {
+		_, err := prismaClient.User.FindUnique(db.User.ID.Equals(user.ID)).Update(db.User.Role.Set(role)).Exec(ctx)
+		assert.NoError(t, err)
+	}
+
+	// Act
+	setRole(backend.RoleRider)
+	demoted, demotedErr := store.Authenticate(ctx, created.Key)
+	setRole(backend.RoleAdmin)
+	promoted, promotedErr := store.Authenticate(ctx, created.Key)
+
+	// Assert
+	assert.NoError(t, demotedErr)
+	assert.Equal(t, backend.RoleRider, demoted.Role)
+	assert.NoError(t, promotedErr)
+	assert.Equal(t, backend.RoleOperator, promoted.Role)
+}
@@ -0,0 +1,124 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func authDialer(authService pb.AuthServer) func(context.Context, string) (net.Conn, error) {
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer()
+	pb.RegisterAuthServer(s, authService)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+	return func(ctx context.Context, s string) (net.Conn, error) {
+		return lis.Dial()
+	}
+}
+
+func setupAuthService(t *testing.T) (pb.AuthClient, *db.PrismaClient, context.Context, func()) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+
+	authService := &backend.AuthenticatorServer{
+		PrismaClient: prismaClient,
+		Hasher:       backend.NewBcryptHasher(4),
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(authDialer(authService)), grpc.WithInsecure())
+	assert.NoError(t, err)
+
+	client := pb.NewAuthClient(conn)
+
+	cleanup := func() {
+		conn.Close()
+		prismaClient.Disconnect()
+	}
+
+	return client, prismaClient, ctx, cleanup
+}
+
+func registerTestUser(t *testing.T, client pb.AuthClient, ctx context.Context) (string, string) {
+	email := fmt.Sprintf("auth-test-%d@example.com", time.Now().UnixNano())
+	password := "tandem-velodrome-42"
+	_, err := client.Register(ctx, &pb.RegisterRequest{
+		Email:    email,
+		Password: password,
+		Name:     "Test",
+		Surname:  "User",
+		Age:      30,
+	})
+	assert.NoError(t, err)
+	return email, password
+}
+
+func deleteTestUser(t *testing.T, prismaClient *db.PrismaClient, ctx context.Context, email string) {
+	_, err := prismaClient.User.FindUnique(
+		db.User.Email.Equals(email),
+	).Delete().Exec(ctx)
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRotationGRPC(t *testing.T) {
+	client, prismaClient, ctx, cleanup := setupAuthService(t)
+	defer cleanup()
+
+	email, password := registerTestUser(t, client, ctx)
+	defer deleteTestUser(t, prismaClient, ctx, email)
+
+	login, err := client.Login(ctx, &pb.LoginRequest{Email: email, Password: password})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, login.Token)
+	assert.NotEmpty(t, login.RefreshToken)
+
+	// Act
+	refreshed, err := client.Refresh(ctx, &pb.RefreshRequest{RefreshToken: login.RefreshToken})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEqual(t, login.RefreshToken, refreshed.RefreshToken)
+
+	// presenting the rotated token again revokes the whole family
+	_, err = client.Refresh(ctx, &pb.RefreshRequest{RefreshToken: login.RefreshToken})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.Refresh(ctx, &pb.RefreshRequest{RefreshToken: refreshed.RefreshToken})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestLogoutRevokesRefreshTokenGRPC(t *testing.T) {
+	client, prismaClient, ctx, cleanup := setupAuthService(t)
+	defer cleanup()
+
+	email, password := registerTestUser(t, client, ctx)
+	defer deleteTestUser(t, prismaClient, ctx, email)
+
+	login, err := client.Login(ctx, &pb.LoginRequest{Email: email, Password: password})
+	assert.NoError(t, err)
+
+	// Act
+	_, err = client.Logout(ctx, &pb.LogoutRequest{RefreshToken: login.RefreshToken})
+
+	// Assert
+	assert.NoError(t, err)
+	_, err = client.Refresh(ctx, &pb.RefreshRequest{RefreshToken: login.RefreshToken})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,235 @@
+package backend
+
+import (
+	"context"
+	"db"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultUserPageSize = 50
+	maxUserPageSize     = 100
+)
+
+type AdminServer struct {
+	UnimplementedAdminServiceServer
+	PrismaClient *db.PrismaClient
+	// Tokens defaults to a TokenStore backed by PrismaClient when nil.
+	Tokens *TokenStore
+
+	tokensOnce sync.Once
+}
+
+func (server *AdminServer) tokens() *TokenStore {
+	server.tokensOnce.Do(func() {
+		if server.Tokens == nil {
+			server.Tokens = &TokenStore{PrismaClient: server.PrismaClient}
+		}
+	})
+	return server.Tokens
+}
+
+func toManagedUser(user *db.UserModel) *ManagedUser {
+	name, _ := user.Name()
+	reply := &ManagedUser{
+		Id:            int32(user.ID),
+		Email:         user.Email,
+		Name:          name,
+		Surname:       user.Surname,
+		Age:           int32(user.Age),
+		Role:          user.Role,
+		EmailVerified: user.EmailVerified,
+		MfaEnabled:    user.MfaEnabled,
+		CreatedAt:     timestamppb.New(user.CreatedAt),
+		UpdatedAt:     timestamppb.New(user.UpdatedAt),
+	}
+	if t, ok := user.DisabledAt(); ok {
+		reply.Disabled = true
+		reply.DisabledAt = timestamppb.New(t)
+	}
+	return reply
+}
+
+func (server *AdminServer) findUser(ctx context.Context, id int32) (*db.UserModel, error) {
+	user, err := server.PrismaClient.User.FindUnique(
+		db.User.ID.Equals(int(id)),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "user %d not found", id)
+	}
+	return user, err
+}
+
+// notSelf keeps admins from locking themselves out.
+func notSelf(ctx context.Context, user *db.UserModel, action string) error {
+	email, err := CurrentUser(ctx)
+	if err != nil {
+		return err
+	}
+	if user.Email == email {
+		return status.Errorf(codes.FailedPrecondition, "you cannot %s your own account", action)
+	}
+	return nil
+}
+
+/*
+	curl -X GET 'http://localhost:8080/v1/admin/users?email_prefix=john&role=rider&page=1&page_size=20' \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *AdminServer) ListUsers(ctx context.Context, req *ListUsersRequest) (*ListUsersReply, error) {
+	var filters []db.UserWhereParam
+	if req.EmailPrefix != "" {
+		filters = append(filters, db.User.Email.StartsWith(req.EmailPrefix))
+	}
+	if req.Role != "" {
+		if !ValidRole(req.Role) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid role %q", req.Role)
+		}
+		filters = append(filters, db.User.Role.Equals(req.Role))
+	}
+	if req.CreatedAfter != nil {
+		filters = append(filters, db.User.CreatedAt.Gte(req.CreatedAfter.AsTime()))
+	}
+	if req.CreatedBefore != nil {
+		filters = append(filters, db.User.CreatedAt.Lt(req.CreatedBefore.AsTime()))
+	}
+
+	take, skip := paginate(req.Page, req.PageSize, defaultUserPageSize, maxUserPageSize)
+	users, err := server.PrismaClient.User.FindMany(filters...).OrderBy(
+		db.User.CreatedAt.Order(db.SortOrderDesc),
+		db.User.ID.Order(db.SortOrderDesc),
+	).Take(take).Skip(skip).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply := &ListUsersReply{}
+	for i := range users {
+		reply.Users = append(reply.Users, toManagedUser(&users[i]))
+	}
+	return reply, nil
+}
+
+/*
+	curl -X GET http://localhost:8080/v1/admin/users/1 \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *AdminServer) GetUser(ctx context.Context, req *GetUserRequest) (*ManagedUser, error) {
+	user, err := server.findUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	rentals, err := countRows(ctx, server.PrismaClient, `SELECT COUNT(*) AS "count" FROM "Rental" WHERE "userId" = $1`, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	active, err := countRows(ctx, server.PrismaClient, `SELECT COUNT(*) AS "count" FROM "Rental" WHERE "userId" = $1 AND "endTime" IS NULL`, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	reply := toManagedUser(user)
+	reply.RentalCount = int32(rentals)
+	reply.ActiveRentalCount = int32(active)
+	return reply, nil
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/admin/users/1/disable \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{}'
+*/
+func (server *AdminServer) DisableUser(ctx context.Context, req *DisableUserRequest) (*ManagedUser, error) {
+	user, err := server.findUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := notSelf(ctx, user, "disable"); err != nil {
+		return nil, err
+	}
+	if _, disabled := user.DisabledAt(); disabled {
+		return toManagedUser(user), nil
+	}
+	updated, err := server.PrismaClient.User.FindUnique(
+		db.User.ID.Equals(user.ID),
+	).Update(
+		db.User.DisabledAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := server.tokens().RevokeUserRefreshTokens(ctx, user.ID); err != nil {
+		log.Printf("Could not end sessions of disabled user %d: %v", user.ID, err)
+		return nil, status.Error(codes.Internal, "user was disabled but could not be logged out")
+	}
+	log.Printf("User %d was disabled", user.ID)
+	return toManagedUser(updated), nil
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/admin/users/1/enable \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{}'
+*/
+func (server *AdminServer) EnableUser(ctx context.Context, req *EnableUserRequest) (*ManagedUser, error) {
+	user, err := server.findUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := server.PrismaClient.User.FindUnique(
+		db.User.ID.Equals(user.ID),
+	).Update(
+		db.User.DisabledAt.SetOptional(nil),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toManagedUser(updated), nil
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/admin/users/1/role \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{
+	        "role": "operator"
+	      }'
+*/
+func (server *AdminServer) SetRole(ctx context.Context, req *SetRoleRequest) (*ManagedUser, error) {
+	if !ValidRole(req.Role) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid role %q", req.Role)
+	}
+	user, err := server.findUser(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if err := notSelf(ctx, user, "change the role of"); err != nil {
+		return nil, err
+	}
+	if user.Role == req.Role {
+		return toManagedUser(user), nil
+	}
+	updated, err := server.PrismaClient.User.FindUnique(
+		db.User.ID.Equals(user.ID),
+	).Update(
+		db.User.Role.Set(req.Role),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// access tokens carry the role, so the old one would otherwise stay in
+	// effect until they expire
+	if err := server.tokens().RevokeUserRefreshTokens(ctx, user.ID); err != nil {
+		log.Printf("Could not end sessions of user %d: %v", user.ID, err)
+		return nil, status.Errorf(codes.Internal, "role was changed but user %d could not be logged out", user.ID)
+	}
+	log.Printf("Role of user %d changed from %s to %s", user.ID, user.Role, req.Role)
+	return toManagedUser(updated), nil
+}
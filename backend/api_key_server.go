@@ -0,0 +1,259 @@
+package backend
+
+import (
+	"backend/auth"
+	"context"
+	"crypto/rand"
+	"db"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// apiKeyPrefix marks BikeRental keys so secret scanners can spot leaks.
+const apiKeyPrefix = "bk_"
+
+// APIKeyStore authenticates the x-api-key header. Keys are only ever
+// stored as SHA-256 hashes.
+type APIKeyStore struct {
+	PrismaClient *db.PrismaClient
+}
+
+// Authenticate is meant to be used as the AuthenticateAPIKey of the
+// server's auth.Interceptor.
+func (s *APIKeyStore) Authenticate(ctx context.Context, key string) (*auth.Identity, error) {
+	stored, err := s.PrismaClient.APIKey.FindUnique(
+		db.APIKey.KeyHash.Equals(hashToken(key)),
+	).With(
+		db.APIKey.User.Fetch(),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not check API key: %v", err)
+	}
+	if _, revoked := stored.RevokedAt(); revoked {
+		return nil, status.Error(codes.Unauthenticated, "API key has been revoked")
+	}
+	if expiresAt, ok := stored.ExpiresAt(); ok && time.Now().After(expiresAt) {
+		return nil, status.Error(codes.Unauthenticated, "API key has expired")
+	}
+
+	id := &auth.Identity{
+		Role:     stored.Role,
+		APIKeyID: stored.ID,
+		Scopes:   strings.Fields(stored.Scopes),
+	}
+	// the role at creation is a ceiling: a demoted user's keys lose the
+	// old role, and a promotion does not widen keys handed out before
+	if user, ok := stored.User(); ok {
+		if _, disabled := user.DisabledAt(); disabled {
+			return nil, status.Error(codes.Unauthenticated, "account is disabled")
+		}
+		id.Email = user.Email
+		id.Role = lowerRole(stored.Role, user.Role)
+	}
+
+	// recording every single use would mean a write per request
+	if lastUsed, ok := stored.LastUsedAt(); !ok || time.Since(lastUsed) > time.Minute {
+		_, err := s.PrismaClient.APIKey.FindUnique(
+			db.APIKey.ID.Equals(stored.ID),
+		).Update(
+			db.APIKey.LastUsedAt.Set(time.Now()),
+		).Exec(ctx)
+		if err != nil {
+			log.Printf("Could not record use of API key %d: %v", stored.ID, err)
+		}
+	}
+	return id, nil
+}
+
+type ApiKeyServer struct {
+	UnimplementedApiKeyServiceServer
+	PrismaClient *db.PrismaClient
+}
+
+func toApiKey(key *db.APIKeyModel) *ApiKey {
+	reply := &ApiKey{
+		Id:        int32(key.ID),
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Role:      key.Role,
+		Scopes:    strings.Fields(key.Scopes),
+		CreatedAt: timestamppb.New(key.CreatedAt),
+	}
+	if userID, ok := key.UserID(); ok {
+		reply.UserId = int32(userID)
+	}
+	if t, ok := key.ExpiresAt(); ok {
+		reply.ExpiresAt = timestamppb.New(t)
+	}
+	if t, ok := key.RevokedAt(); ok {
+		reply.RevokedAt = timestamppb.New(t)
+	}
+	if t, ok := key.LastUsedAt(); ok {
+		reply.LastUsedAt = timestamppb.New(t)
+	}
+	return reply
+}
+
+func newAPIKey() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return apiKeyPrefix + base64.RawURLEncoding.EncodeToString(b)
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/api-keys \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{
+	        "name": "reporting",
+	        "scopes": ["bikes:read", "rentals:read"]
+	      }'
+*/
+func (server *ApiKeyServer) CreateApiKey(ctx context.Context, req *CreateApiKeyRequest) (*CreateApiKeyReply, error) {
+	id, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "not authenticated")
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if len(req.Scopes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one scope is required")
+	}
+	for _, scope := range req.Scopes {
+		if !ValidScope(scope) {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown scope %q", scope)
+		}
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.AsTime().After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+	}
+
+	role := id.Role
+	if req.ServiceAccount {
+		if id.Role != RoleAdmin {
+			return nil, status.Error(codes.PermissionDenied, "only admins can create service account keys")
+		}
+		if !ValidRole(req.Role) {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid role %q", req.Role)
+		}
+		role = req.Role
+	}
+	// keys cannot be used to create more keys, so the caller holds an
+	// access token here and its second factor can be checked
+	if RequireStaffMFA && IsStaff(role) && !id.MFA {
+		return nil, status.Error(codes.PermissionDenied, "creating a staff key requires two-factor authentication")
+	}
+
+	key := newAPIKey()
+	var params []db.APIKeySetParam
+	if req.ExpiresAt != nil {
+		params = append(params, db.APIKey.ExpiresAt.Set(req.ExpiresAt.AsTime()))
+	}
+	if !req.ServiceAccount {
+		email, err := CurrentUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, db.APIKey.User.Link(db.User.Email.Equals(email)))
+	}
+	created, err := server.PrismaClient.APIKey.CreateOne(
+		db.APIKey.Name.Set(strings.TrimSpace(req.Name)),
+		db.APIKey.Prefix.Set(key[:len(apiKeyPrefix)+6]),
+		db.APIKey.KeyHash.Set(hashToken(key)),
+		db.APIKey.Role.Set(role),
+		db.APIKey.Scopes.Set(strings.Join(req.Scopes, " ")),
+		params...,
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Could not create API key: %v", err)
+		return nil, status.Error(codes.Internal, "could not create API key")
+	}
+	return &CreateApiKeyReply{
+		ApiKey: toApiKey(created),
+		Key:    key,
+	}, nil
+}
+
+/*
+	curl -X GET http://localhost:8080/v1/api-keys \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *ApiKeyServer) ListApiKeys(ctx context.Context, req *ListApiKeysRequest) (*ListApiKeysReply, error) {
+	email, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter := []db.APIKeyWhereParam{
+		db.APIKey.User.Where(db.User.Email.Equals(email)),
+	}
+	if CurrentRole(ctx) == RoleAdmin {
+		filter = append(filter, db.APIKey.UserID.IsNull())
+	}
+	keys, err := server.PrismaClient.APIKey.FindMany(
+		db.APIKey.Or(filter...),
+	).OrderBy(
+		db.APIKey.ID.Order(db.SortOrderAsc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply := &ListApiKeysReply{}
+	for i := range keys {
+		reply.ApiKeys = append(reply.ApiKeys, toApiKey(&keys[i]))
+	}
+	return reply, nil
+}
+
+/*
+	curl -X DELETE http://localhost:8080/v1/api-keys/1 \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *ApiKeyServer) RevokeApiKey(ctx context.Context, req *RevokeApiKeyRequest) (*RevokeApiKeyReply, error) {
+	email, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key, err := server.PrismaClient.APIKey.FindUnique(
+		db.APIKey.ID.Equals(int(req.Id)),
+	).With(
+		db.APIKey.User.Fetch(),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "API key not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	owner, owned := key.User()
+	if CurrentRole(ctx) != RoleAdmin && (!owned || owner.Email != email) {
+		// do not reveal that the key exists
+		return nil, status.Error(codes.NotFound, "API key not found")
+	}
+
+	_, err = server.PrismaClient.APIKey.FindMany(
+		db.APIKey.ID.Equals(key.ID),
+		db.APIKey.RevokedAt.IsNull(),
+	).Update(
+		db.APIKey.RevokedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &RevokeApiKeyReply{
+		Reply: fmt.Sprintf("API key %d was revoked", key.ID),
+	}, nil
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientIP returns the address the call originates from. Calls relayed by
+// the local REST gateway arrive from a loopback address, so for those the
+// last "x-forwarded-for" entry, which the gateway appends itself, is used.
+// Entries further left come from the client and cannot be trusted.
+func ClientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !ip.IsLoopback() {
+		return host
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	forwarded := md.Get("x-forwarded-for")
+	if len(forwarded) == 0 {
+		return host
+	}
+	hops := strings.Split(forwarded[len(forwarded)-1], ",")
+	if last := strings.TrimSpace(hops[len(hops)-1]); last != "" {
+		return last
+	}
+	return host
+}
+
+// UserAgent returns the client's User-Agent. The REST gateway forwards the
+// browser's as "grpcgateway-user-agent".
+func UserAgent(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if v := md.Get(key); len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+// Package auth authenticates gRPC calls with bearer tokens and carries the
+// caller's identity through the request context.
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Identity describes the authenticated caller of an RPC.
+type Identity struct {
+	Email string
+	Role  string
+	// TokenID is the jti of the access token the call was made with.
+	TokenID   string
+	ExpiresAt time.Time
+	// SessionID is the login session the access token belongs to.
+	SessionID string
+	// MFA is set when the caller passed a second factor.
+	MFA bool
+	// APIKeyID is set when the call was authenticated with an API key
+	// instead of an access token. Scopes then limits what it may call.
+	APIKeyID int
+	Scopes   []string
+}
+
+type identityKey struct{}
+
+// NewContext returns a copy of ctx carrying id.
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the identity stored in ctx by the interceptor.
+func FromContext(ctx context.Context) (*Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(*Identity)
+	return id, ok && id != nil
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Authenticator turns a bearer token into the caller's identity. Errors
+// should be gRPC status errors; anything else is reported as Unauthenticated.
+type Authenticator func(ctx context.Context, token string) (*Identity, error)
+
+// Authorizer decides whether id may call fullMethod.
+type Authorizer func(fullMethod string, id *Identity) error
+
+// Interceptor authenticates every RPC except those listed in Public.
+type Interceptor struct {
+	Authenticate Authenticator
+	// AuthenticateAPIKey is optional. When set, calls carrying an
+	// "x-api-key" header are authenticated with it instead of Authenticate.
+	AuthenticateAPIKey Authenticator
+	// Authorize is optional, without it every authenticated caller is allowed.
+	Authorize Authorizer
+	// Public holds full method names, e.g. "/authenticator.Auth/Login",
+	// that can be called without a token.
+	Public map[string]bool
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream authenticates streaming RPCs the same way Unary does. The handler
+// receives a wrapped stream whose Context carries the caller's identity.
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &ServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// ServerStream overrides the context of an authenticated stream.
+type ServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *ServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (i *Interceptor) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if i.Public[fullMethod] {
+		return ctx, nil
+	}
+	var id *Identity
+	var err error
+	if key, ok := APIKey(ctx); ok && i.AuthenticateAPIKey != nil {
+		id, err = i.AuthenticateAPIKey(ctx, key)
+	} else {
+		var token string
+		if token, err = BearerToken(ctx); err != nil {
+			return nil, err
+		}
+		id, err = i.Authenticate(ctx, token)
+	}
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+	}
+	if i.Authorize != nil {
+		if err := i.Authorize(fullMethod, id); err != nil {
+			return nil, err
+		}
+	}
+	return NewContext(ctx, id), nil
+}
+
+// BearerToken extracts the token from the "authorization" metadata of an
+// incoming call. Only the "Bearer" scheme is accepted.
+func BearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing authorization header")
+	}
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", status.Error(codes.Unauthenticated, `authorization header must use the "Bearer" scheme`)
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	return token, nil
+}
+
+// APIKey returns the "x-api-key" metadata of an incoming call.
+func APIKey(ctx context.Context) (string, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("x-api-key")
+	if len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(values[0]), true
+}
@@ -1,32 +1,101 @@
 package backend
 
 import (
+	"backend/auth"
 	"context"
 	"db"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
 
-	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type AuthenticatorServer struct {
 	UnimplementedAuthServer
 	PrismaClient *db.PrismaClient
+	// Hasher defaults to NewPasswordHasherFromEnv when nil.
+	Hasher PasswordHasher
+	// PasswordPolicy defaults to NewPasswordPolicyFromEnv when nil.
+	PasswordPolicy *PasswordPolicy
+	// Tokens defaults to a TokenStore backed by PrismaClient when nil.
+	Tokens *TokenStore
+	// Mailer defaults to NewMailerFromEnv, or stdout, when nil.
+	Mailer Mailer
+	// Throttle defaults to NewLoginThrottleFromEnv when nil.
+	Throttle *LoginThrottle
+
+	defaults sync.Once
+}
+
+// setDefaults fills in the fields left nil. Requests are served
+// concurrently, so it runs once rather than on each field's first use.
+func (s *AuthenticatorServer) setDefaults() {
+	s.defaults.Do(func() {
+		if s.Hasher == nil {
+			s.Hasher = NewPasswordHasherFromEnv()
+		}
+		if s.PasswordPolicy == nil {
+			s.PasswordPolicy = NewPasswordPolicyFromEnv()
+		}
+		if s.Tokens == nil {
+			s.Tokens = &TokenStore{PrismaClient: s.PrismaClient}
+		}
+		if s.Mailer == nil {
+			// the server sets its mailer on start, so only tests and
+			// tools get here without one; they fall back to stdout
+			mailer, err := NewMailerFromEnv()
+			if err != nil {
+				mailer = &FileMailer{From: stringFromEnv("MAIL_FROM", "BikeRental <no-reply@bikerental.local>")}
+			}
+			s.Mailer = mailer
+		}
+		if s.Throttle == nil {
+			s.Throttle = NewLoginThrottleFromEnv(s.PrismaClient)
+		}
+	})
+}
+
+func (s *AuthenticatorServer) hasher() PasswordHasher {
+	s.setDefaults()
+	return s.Hasher
+}
+
+func (s *AuthenticatorServer) tokens() *TokenStore {
+	s.setDefaults()
+	return s.Tokens
+}
+
+func (s *AuthenticatorServer) throttle() *LoginThrottle {
+	s.setDefaults()
+	return s.Throttle
 }
 
+func (s *AuthenticatorServer) passwordPolicy() *PasswordPolicy {
+	s.setDefaults()
+	return s.PasswordPolicy
+}
+
+func (s *AuthenticatorServer) mailer() Mailer {
+	s.setDefaults()
+	return s.Mailer
+}
+
+// CurrentUser returns the email of the authenticated caller.
 func CurrentUser(ctx context.Context) (string, error) {
-	md, ok := metadata.FromOutgoingContext(ctx)
+	id, ok := auth.FromContext(ctx)
 	if !ok {
-		return "", fmt.Errorf("missing metadata")
+		return "", status.Error(codes.Unauthenticated, "not authenticated")
 	}
-	current_user := md["current_user"]
-	return current_user[0], nil
+	return id.Email, nil
 }
 
 /*
 	curl -X POST http://localhost:8080/v1/auth/protected \
 	  -H 'Content-Type: application/json' \
-	  -H 'Authorization: $TOKEN \
+	  -H 'Authorization: Bearer $TOKEN \
 	  -d '{
 	        "text": "Sample text message"
 	      }'
@@ -52,30 +121,113 @@ func (s *AuthenticatorServer) SampleProtected(ctx context.Context, in *Protected
 func (s *AuthenticatorServer) Login(ctx context.Context, in *LoginRequest) (*LoginReply, error) {
 	log.Println("Login attempt for email:", in.Email)
 
+	ip := auth.ClientIP(ctx)
+	if err := s.throttle().Check(ctx, in.Email, ip); err != nil {
+		log.Printf("Login for %s from %s throttled", in.Email, ip)
+		return nil, err
+	}
+
 	user, err := s.PrismaClient.User.FindUnique(
 		db.User.Email.Equals(in.Email),
 	).Exec(ctx)
 
 	if err != nil {
 		log.Printf("User not found: %v", err)
+		s.loginFailed(ctx, in.Email, ip)
 		return nil, fmt.Errorf("incorrect email or password")
 	}
 
-	if user.Password != in.Password {
+	ok, err := s.hasher().Verify(user.Password, in.Password)
+	if err != nil {
+		log.Printf("Could not verify password: %v", err)
+	}
+	if !ok {
 		log.Println("Invalid password")
+		s.loginFailed(ctx, in.Email, ip)
 		return nil, fmt.Errorf("incorrect email or password")
 	}
 
-	token, err := GenerateJWT(in.Email)
+	// upgrade plaintext rows and hashes made with outdated parameters
+	if s.hasher().NeedsRehash(user.Password) {
+		if err := s.rehashPassword(ctx, user.ID, in.Password); err != nil {
+			log.Printf("Could not upgrade password hash for user %d: %v", user.ID, err)
+		}
+	}
+
+	// the failure counter is only reset once the second factor is verified
+	// too, so it keeps limiting guesses of the TOTP code
+	if user.MfaEnabled {
+		return s.mfaChallenge(user)
+	}
+	if err := s.throttle().Succeed(ctx, in.Email); err != nil {
+		log.Printf("Could not reset failed logins for %s: %v", in.Email, err)
+	}
+	return s.issueTokens(ctx, user, false)
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/auth/refresh \
+	  -H 'Content-Type: application/json' \
+	  -d '{
+	        "refresh_token": "$REFRESH_TOKEN"
+	      }'
+*/
+func (s *AuthenticatorServer) Refresh(ctx context.Context, in *RefreshRequest) (*LoginReply, error) {
+	refreshToken, stored, err := s.tokens().RotateRefreshToken(ctx, in.RefreshToken)
+	if errors.Is(err, ErrRefreshTokenReused) {
+		log.Printf("Refresh token reuse detected, token family revoked")
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if errors.Is(err, ErrRefreshTokenInvalid) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		log.Printf("Could not rotate refresh token: %v", err)
+		return nil, status.Error(codes.Internal, "could not refresh token")
+	}
+
+	user := stored.User()
+	if err := requireEnabled(user); err != nil {
+		return nil, err
+	}
+	token, err := GenerateSessionJWT(stored.FamilyID, user.Email, user.Role, authMethods(stored.Mfa)...)
 	if err != nil {
 		log.Printf("Error generating token: %v", err)
 		return nil, fmt.Errorf("could not generate token: %v", err)
 	}
+	return &LoginReply{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(AccessTokenTTL.Seconds()),
+	}, nil
+}
 
-	log.Printf("Generated token: %s", token)
+/*
+	curl -X POST http://localhost:8080/v1/auth/logout \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{
+	        "refresh_token": "$REFRESH_TOKEN"
+	      }'
+*/
+func (s *AuthenticatorServer) Logout(ctx context.Context, in *LogoutRequest) (*LogoutReply, error) {
+	err := s.tokens().RevokeRefreshToken(ctx, in.RefreshToken)
+	if err != nil && !errors.Is(err, ErrRefreshTokenInvalid) {
+		log.Printf("Could not revoke refresh token: %v", err)
+		return nil, status.Error(codes.Internal, "could not log out")
+	}
 
-	return &LoginReply{
-		Token: token,
+	// the access token is optional, it may already have expired
+	if token, err := auth.BearerToken(ctx); err == nil {
+		if claims, err := VerifyJWT(token); err == nil {
+			if err := s.tokens().RevokeAccessToken(ctx, claims); err != nil {
+				log.Printf("Could not revoke access token: %v", err)
+				return nil, status.Error(codes.Internal, "could not log out")
+			}
+		}
+	}
+	return &LogoutReply{
+		Reply: "Logged out",
 	}, nil
 }
 
@@ -91,12 +243,21 @@ func (s *AuthenticatorServer) Login(ctx context.Context, in *LoginRequest) (*Log
 	      }'
 */
 func (s *AuthenticatorServer) Register(ctx context.Context, in *RegisterRequest) (*RegisterReply, error) {
+	if err := s.passwordPolicy().Validate(in.Password); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	hash, err := s.hasher().Hash(in.Password)
+	if err != nil {
+		log.Printf("failed to hash password: %v", err)
+		return nil, fmt.Errorf("failed to register user")
+	}
 	obj, err := s.PrismaClient.User.CreateOne(
 		db.User.Email.Set(in.Email),
-		db.User.Password.Set(in.Password),
+		db.User.Password.Set(hash),
 		db.User.Surname.Set(in.Surname),
 		db.User.Age.Set(int(in.Age)),
 		db.User.Name.Set(in.Name),
+		db.User.EmailVerified.Set(false),
 	).Exec(ctx)
 
 	if err != nil {
@@ -104,7 +265,89 @@ func (s *AuthenticatorServer) Register(ctx context.Context, in *RegisterRequest)
 		return nil, fmt.Errorf("failed to register user")
 	}
 
+	s.sendVerificationEmail(ctx, obj)
+
 	return &RegisterReply{
-		Reply: fmt.Sprintf("Congratulations, User id: %s got created!", obj.ID),
+		Reply: fmt.Sprintf("Congratulations, User id: %d got created!", obj.ID),
 	}, nil
 }
+
+/*
+	curl -X POST http://localhost:8080/v1/auth/unlock \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{
+	        "email": "newuser@example.com"
+	      }'
+*/
+func (s *AuthenticatorServer) UnlockAccount(ctx context.Context, in *UnlockAccountRequest) (*UnlockAccountReply, error) {
+	if in.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+	if err := s.throttle().Unlock(ctx, in.Email, in.Ip); err != nil {
+		log.Printf("Could not unlock %s: %v", in.Email, err)
+		return nil, status.Error(codes.Internal, "could not unlock account")
+	}
+	return &UnlockAccountReply{
+		Reply: fmt.Sprintf("Account %s unlocked", in.Email),
+	}, nil
+}
+
+// loginFailed records a failed login. Failing to record it must not turn
+// a wrong password into a different error, so problems are only logged.
+func (s *AuthenticatorServer) loginFailed(ctx context.Context, email, ip string) {
+	if err := s.throttle().Fail(ctx, email, ip); err != nil {
+		log.Printf("Could not record failed login for %s: %v", email, err)
+	}
+}
+
+func (s *AuthenticatorServer) rehashPassword(ctx context.Context, userID int, password string) error {
+	hash, err := s.hasher().Hash(password)
+	if err != nil {
+		return err
+	}
+	_, err = s.PrismaClient.User.FindUnique(
+		db.User.ID.Equals(userID),
+	).Update(
+		db.User.Password.Set(hash),
+	).Exec(ctx)
+	return err
+}
+
+// requireEnabled refuses to log in users an admin has disabled.
+func requireEnabled(user *db.UserModel) error {
+	if _, disabled := user.DisabledAt(); disabled {
+		return status.Error(codes.PermissionDenied, "account is disabled")
+	}
+	return nil
+}
+
+// issueTokens completes a login. mfa tells whether a second factor was
+// verified.
+func (s *AuthenticatorServer) issueTokens(ctx context.Context, user *db.UserModel, mfa bool) (*LoginReply, error) {
+	if err := requireEnabled(user); err != nil {
+		return nil, err
+	}
+	sessionID, refreshToken, err := s.tokens().StartSession(ctx, user.ID, mfa)
+	if err != nil {
+		log.Printf("Error generating refresh token: %v", err)
+		return nil, fmt.Errorf("could not generate token: %v", err)
+	}
+	token, err := GenerateSessionJWT(sessionID, user.Email, user.Role, authMethods(mfa)...)
+	if err != nil {
+		log.Printf("Error generating token: %v", err)
+		return nil, fmt.Errorf("could not generate token: %v", err)
+	}
+	return &LoginReply{
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+func authMethods(mfa bool) []string {
+	if mfa {
+		return []string{AMRPassword, AMROTP}
+	}
+	return []string{AMRPassword}
+}
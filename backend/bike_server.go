@@ -3,7 +3,19 @@ package backend
 import (
 	"context"
 	"db"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultBikePageSize = 50
+	maxBikePageSize     = 200
 )
 
 type BikeServer struct {
@@ -11,77 +23,182 @@ type BikeServer struct {
 	PrismaClient *db.PrismaClient
 }
 
+func toBike(bike *db.BikeModel) *Bike {
+	reply := &Bike{
+		Id:        int32(bike.ID),
+		Model:     bike.Model,
+		Type:      bike.Type,
+		Status:    bikeStatus(bike),
+		CreatedAt: timestamppb.New(bike.CreatedAt),
+		UpdatedAt: timestamppb.New(bike.UpdatedAt),
+	}
+	if id, ok := bike.StationID(); ok {
+		reply.StationId = int32(id)
+	}
+	if id, ok := bike.DockID(); ok {
+		reply.DockId = int32(id)
+	}
+	if level, ok := bike.BatteryLevel(); ok {
+		battery := int32(level)
+		reply.BatteryLevel = &battery
+	}
+	return reply
+}
+
+// dockOccupied turns the unique constraint on Bike.dockId into an error
+// for the caller when two bikes are put into the same dock at once.
+func dockOccupied(err error, dockID int32) error {
+	if _, ok := db.IsErrUniqueConstraint(err); ok {
+		return status.Errorf(codes.FailedPrecondition, "dock %d is occupied", dockID)
+	}
+	return err
+}
+
 /*
 	curl -X GET http://localhost:8080/v1/bikes/1 \
-	  -H 'Authorization: $TOKEN'
+	  -H 'Authorization: Bearer $TOKEN'
 */
 func (server *BikeServer) GetBike(ctx context.Context, req *GetBikeRequest) (*Bike, error) {
-	rental, err := server.PrismaClient.Bike.FindUnique(
+	bike, err := server.PrismaClient.Bike.FindUnique(
 		db.Bike.ID.Equals(int(req.Id)),
 	).Exec(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &Bike{
-		Id:     int32(rental.ID),
-		Model:  rental.Model,
-		Status: rental.Status,
-	}, nil
+	return toBike(bike), nil
 }
 
 /*
 	curl -X POST http://localhost:8080/v1/bikes \
 	  -H 'Content-Type: application/json' \
-	  -H 'Authorization: $TOKEN' \
+	  -H 'Authorization: Bearer $TOKEN' \
 	  -d '{
 	        "model": "Mountain Bike",
-	        "status": "available"
+	        "type": "electric",
+	        "status": "AVAILABLE",
+	        "dock_id": 3
 	      }'
 */
 func (server *BikeServer) CreateBike(ctx context.Context, req *CreateBikeRequest) (*Bike, error) {
-	rental, err := server.PrismaClient.Bike.CreateOne(
+	initial := req.Status
+	if initial == BikeStatus_BIKE_STATUS_UNSPECIFIED {
+		initial = BikeStatus_AVAILABLE
+	}
+	if initial != BikeStatus_AVAILABLE && initial != BikeStatus_MAINTENANCE {
+		return nil, status.Errorf(codes.InvalidArgument, "new bikes must be AVAILABLE or in MAINTENANCE, not %s", initial)
+	}
+	bikeType := req.Type
+	if bikeType == "" {
+		bikeType = DefaultBikeType
+	}
+	var placement []db.BikeSetParam
+	if req.DockId != 0 {
+		dock, err := freeDock(ctx, server.PrismaClient, int(req.DockId))
+		if err != nil {
+			return nil, err
+		}
+		placement = append(placement,
+			db.Bike.Dock.Link(db.Dock.ID.Equals(dock.ID)),
+			db.Bike.Station.Link(db.Station.ID.Equals(dock.StationID)),
+		)
+	}
+	bike, err := server.PrismaClient.Bike.CreateOne(
 		db.Bike.Model.Set(req.Model),
-		db.Bike.Status.Set(req.Status),
+		append([]db.BikeSetParam{
+			db.Bike.Status.Set(initial.String()),
+			db.Bike.Type.Set(bikeType),
+		}, placement...)...,
 	).Exec(ctx)
 	if err != nil {
-		return nil, err
+		return nil, dockOccupied(err, req.DockId)
 	}
-	return &Bike{
-		Id:     int32(rental.ID),
-		Model:  rental.Model,
-		Status: rental.Status,
-	}, nil
+	return toBike(bike), nil
 }
 
 /*
 	curl -X PUT http://localhost:8080/v1/bikes/1 \
 	  -H 'Content-Type: application/json' \
-	  -H 'Authorization: $TOKEN' \
+	  -H 'Authorization: Bearer $TOKEN' \
 	  -d '{
 	        "model": "Road Bike",
-	        "status": "in_service"
+	        "status": "MAINTENANCE",
+	        "dock_id": 4
 	      }'
 */
 func (server *BikeServer) UpdateBike(ctx context.Context, req *UpdateBikeRequest) (*Bike, error) {
-	rental, err := server.PrismaClient.Bike.FindUnique(
+	bike, err := server.PrismaClient.Bike.FindUnique(
 		db.Bike.ID.Equals(int(req.Id)),
-	).Update(
-		db.Bike.Model.Set(req.Model),
-		db.Bike.Status.Set(req.Status),
 	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "bike %d not found", req.Id)
+	}
 	if err != nil {
 		return nil, err
 	}
-	return &Bike{
-		Id:     int32(rental.ID),
-		Model:  rental.Model,
-		Status: req.Status,
-	}, nil
+
+	var params []db.BikeSetParam
+	if req.Model != "" {
+		params = append(params, db.Bike.Model.Set(req.Model))
+	}
+	if req.Type != "" {
+		params = append(params, db.Bike.Type.Set(req.Type))
+	}
+	if req.BatteryLevel != nil {
+		if *req.BatteryLevel < 0 || *req.BatteryLevel > 100 {
+			return nil, status.Error(codes.InvalidArgument, "battery_level must be between 0 and 100")
+		}
+		params = append(params, db.Bike.BatteryLevel.Set(int(*req.BatteryLevel)))
+	}
+	from, to := bikeStatus(bike), req.Status
+	if to != BikeStatus_BIKE_STATUS_UNSPECIFIED && to != from {
+		// a rented bike can still be reported lost
+		if rentalManaged(to) || (rentalManaged(from) && to != BikeStatus_LOST) {
+			return nil, status.Errorf(codes.FailedPrecondition, "bike %d cannot go from %s to %s directly, rentals manage that status", bike.ID, from, to)
+		}
+		if err := checkBikeTransition(bike.ID, from, to); err != nil {
+			return nil, err
+		}
+		params = append(params, db.Bike.Status.Set(to.String()))
+	}
+	if dockID, _ := bike.DockID(); req.DockId != 0 && int(req.DockId) != dockID {
+		if bikeStatus(bike) == BikeStatus_RENTED {
+			return nil, status.Errorf(codes.FailedPrecondition, "bike %d is rented, end the rental to return it", bike.ID)
+		}
+		dock, err := freeDock(ctx, server.PrismaClient, int(req.DockId))
+		if err != nil {
+			return nil, err
+		}
+		params = append(params,
+			db.Bike.DockID.Set(dock.ID),
+			db.Bike.StationID.Set(dock.StationID),
+		)
+	}
+	if len(params) == 0 {
+		return toBike(bike), nil
+	}
+
+	result, err := server.PrismaClient.Bike.FindMany(
+		db.Bike.ID.Equals(bike.ID),
+		db.Bike.Status.Equals(bike.Status),
+	).Update(params...).Exec(ctx)
+	if err != nil {
+		return nil, dockOccupied(err, req.DockId)
+	}
+	if result.Count == 0 {
+		return nil, status.Errorf(codes.Aborted, "bike %d changed concurrently, try again", bike.ID)
+	}
+	updated, err := server.PrismaClient.Bike.FindUnique(
+		db.Bike.ID.Equals(bike.ID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toBike(updated), nil
 }
 
 /*
 	curl -X DELETE http://localhost:8080/v1/bikes/1 \
-	  -H 'Authorization: $TOKEN'
+	  -H 'Authorization: Bearer $TOKEN'
 */
 func (server *BikeServer) DeleteBike(ctx context.Context, req *DeleteBikeRequest) (*DeletedBikeResponse, error) {
 	rental, err := server.PrismaClient.Bike.FindUnique(
@@ -96,25 +213,178 @@ func (server *BikeServer) DeleteBike(ctx context.Context, req *DeleteBikeRequest
 	}, nil
 }
 
+// bikeFilter holds the conditions of a ListBikes filter twice: as Prisma
+// params for the page and as SQL for counting the matches.
+type bikeFilter struct {
+	where []db.BikeWhereParam
+	sql   []string
+	args  []interface{}
+}
+
+// add appends a condition. Each ? in sql becomes a placeholder for arg.
+func (f *bikeFilter) add(where db.BikeWhereParam, sql string, arg interface{}) {
+	f.args = append(f.args, arg)
+	f.where = append(f.where, where)
+	f.sql = append(f.sql, strings.ReplaceAll(sql, "?", fmt.Sprintf("$%d", len(f.args))))
+}
+
+// likeEscaper escapes the LIKE wildcards, so model:"50%" matches literally
+// as Prisma's Contains does.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// bikeFilters turns a ListBikes filter into query conditions.
+func bikeFilters(filter string) (*bikeFilter, error) {
+	terms, err := ParseFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	filters := &bikeFilter{}
+	for _, term := range terms {
+		unsupported := status.Errorf(codes.InvalidArgument, "filter: %s does not support %s", term.Field, term.Op)
+		switch term.Field {
+		case "status":
+			if _, ok := BikeStatus_value[term.Value]; !ok {
+				return nil, status.Errorf(codes.InvalidArgument, "filter: unknown status %q", term.Value)
+			}
+			switch term.Op {
+			case "=":
+				filters.add(db.Bike.Status.Equals(term.Value), `"status" = ?`, term.Value)
+			case "!=":
+				filters.add(db.Bike.Status.Not(term.Value), `"status" <> ?`, term.Value)
+			default:
+				return nil, unsupported
+			}
+		case "type":
+			switch term.Op {
+			case "=":
+				filters.add(db.Bike.Type.Equals(term.Value), `"type" = ?`, term.Value)
+			case "!=":
+				filters.add(db.Bike.Type.Not(term.Value), `"type" <> ?`, term.Value)
+			default:
+				return nil, unsupported
+			}
+		case "model":
+			switch term.Op {
+			case "=":
+				filters.add(db.Bike.Model.Equals(term.Value), `"model" = ?`, term.Value)
+			case ":":
+				filters.add(db.Bike.Model.Contains(term.Value), `"model" LIKE ? ESCAPE '\'`, "%"+likeEscaper.Replace(term.Value)+"%")
+			default:
+				return nil, unsupported
+			}
+		case "station_id":
+			id, err := strconv.Atoi(term.Value)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "filter: station_id needs a number, got %q", term.Value)
+			}
+			switch term.Op {
+			case "=":
+				filters.add(db.Bike.StationID.Equals(id), `"stationId" = ?`, id)
+			case "!=":
+				// bikes out on a rental have no station and are not at id either
+				filters.add(db.Bike.Or(db.Bike.StationID.Not(id), db.Bike.StationID.IsNull()), `("stationId" <> ? OR "stationId" IS NULL)`, id)
+			default:
+				return nil, unsupported
+			}
+		case "create_time":
+			t, err := filterTime(term)
+			if err != nil {
+				return nil, err
+			}
+			switch term.Op {
+			case "<":
+				filters.add(db.Bike.CreatedAt.Lt(t), `"createdAt" < ?`, t)
+			case "<=":
+				filters.add(db.Bike.CreatedAt.Lte(t), `"createdAt" <= ?`, t)
+			case ">":
+				filters.add(db.Bike.CreatedAt.Gt(t), `"createdAt" > ?`, t)
+			case ">=":
+				filters.add(db.Bike.CreatedAt.Gte(t), `"createdAt" >= ?`, t)
+			default:
+				return nil, unsupported
+			}
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "filter: unknown field %q", term.Field)
+		}
+	}
+	return filters, nil
+}
+
+// count returns how many bikes match the filter.
+func (f *bikeFilter) count(ctx context.Context, client *db.PrismaClient) (int, error) {
+	query := `SELECT COUNT(*) AS "count" FROM "Bike"`
+	if len(f.sql) > 0 {
+		query += " WHERE " + strings.Join(f.sql, " AND ")
+	}
+	return countRows(ctx, client, query, f.args...)
+}
+
+// bikeOrder turns a ListBikes order_by into sort keys. The id is added
+// last unless given, so pages stay stable when the other keys tie.
+func bikeOrder(orderBy string) ([]db.BikeOrderByParam, error) {
+	fields, err := ParseOrderBy(orderBy)
+	if err != nil {
+		return nil, err
+	}
+	var order []db.BikeOrderByParam
+	byID := false
+	for _, field := range fields {
+		direction := db.SortOrderAsc
+		if field.Desc {
+			direction = db.SortOrderDesc
+		}
+		switch field.Field {
+		case "id":
+			order = append(order, db.Bike.ID.Order(direction))
+			byID = true
+		case "model":
+			order = append(order, db.Bike.Model.Order(direction))
+		case "type":
+			order = append(order, db.Bike.Type.Order(direction))
+		case "status":
+			order = append(order, db.Bike.Status.Order(direction))
+		case "create_time":
+			order = append(order, db.Bike.CreatedAt.Order(direction))
+		case "update_time":
+			order = append(order, db.Bike.UpdatedAt.Order(direction))
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "order_by: unknown field %q", field.Field)
+		}
+	}
+	if !byID {
+		order = append(order, db.Bike.ID.Order(db.SortOrderAsc))
+	}
+	return order, nil
+}
+
 /*
-	curl -X GET 'http://localhost:8080/v1/bikes?page=1&page_size=10' \
-	  -H 'Authorization: $TOKEN'
+	curl -X GET 'http://localhost:8080/v1/bikes?page=1&page_size=10&filter=status%3DAVAILABLE%20AND%20model%3A%22urban%22&order_by=create_time%20desc' \
+	  -H 'Authorization: Bearer $TOKEN'
 */
 func (server *BikeServer) ListBikes(ctx context.Context, req *ListBikesRequest) (*ListBikesResponse, error) {
-	selected, err := server.PrismaClient.Bike.FindMany().Take(int(req.PageSize)).Skip((int(req.Page) - 1) * int(req.PageSize)).Exec(ctx)
+	filters, err := bikeFilters(req.Filter)
+	if err != nil {
+		return nil, err
+	}
+	order, err := bikeOrder(req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	take, skip := paginate(req.Page, req.PageSize, defaultBikePageSize, maxBikePageSize)
+	selected, err := server.PrismaClient.Bike.FindMany(filters.where...).OrderBy(order...).Take(take).Skip(skip).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	total, err := filters.count(ctx, server.PrismaClient)
 	if err != nil {
 		return nil, err
 	}
 	var bikes []*Bike
 	for _, bike := range selected {
-		bikes = append(bikes, &Bike{
-			Id:     int32(bike.ID),
-			Model:  bike.Model,
-			Status: bike.Status,
-		},
-		)
+		bikes = append(bikes, toBike(&bike))
 	}
 	return &ListBikesResponse{
-		Bikes: bikes,
+		Bikes:     bikes,
+		TotalSize: int32(total),
 	}, nil
 }
@@ -0,0 +1,149 @@
+package backend
+
+import (
+	"context"
+	"db"
+	"log"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// bikeTransitions lists the statuses a bike may move to from each status.
+// RETIRED is final.
+var bikeTransitions = map[BikeStatus][]BikeStatus{
+	BikeStatus_AVAILABLE:   {BikeStatus_RENTED, BikeStatus_RESERVED, BikeStatus_MAINTENANCE, BikeStatus_RETIRED, BikeStatus_LOST},
+	BikeStatus_RESERVED:    {BikeStatus_AVAILABLE, BikeStatus_RENTED, BikeStatus_MAINTENANCE, BikeStatus_LOST},
+	BikeStatus_RENTED:      {BikeStatus_AVAILABLE, BikeStatus_MAINTENANCE, BikeStatus_LOST},
+	BikeStatus_MAINTENANCE: {BikeStatus_AVAILABLE, BikeStatus_RETIRED, BikeStatus_LOST},
+	BikeStatus_LOST:        {BikeStatus_AVAILABLE, BikeStatus_MAINTENANCE, BikeStatus_RETIRED},
+}
+
+// CanTransition reports whether a bike may move from one status to
+// another. Staying in the same status is always allowed.
+func CanTransition(from, to BikeStatus) bool {
+	if from == to {
+		return true
+	}
+	for _, allowed := range bikeTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func checkBikeTransition(bikeID int, from, to BikeStatus) error {
+	if !CanTransition(from, to) {
+		return status.Errorf(codes.FailedPrecondition, "bike %d cannot go from %s to %s", bikeID, from, to)
+	}
+	return nil
+}
+
+// rentalManaged reports whether status is only set by rentals and
+// reservations, never directly through UpdateBike.
+func rentalManaged(s BikeStatus) bool {
+	return s == BikeStatus_RENTED || s == BikeStatus_RESERVED
+}
+
+// bikeStatus reads the status column, which holds the enum name.
+func bikeStatus(bike *db.BikeModel) BikeStatus {
+	return BikeStatus(BikeStatus_value[bike.Status])
+}
+
+// legacyBikeStatuses maps the free form values stored before BikeStatus
+// existed. Values saying the bike is in use only become RENTED if it has
+// an ongoing rental.
+var legacyBikeStatuses = map[string]BikeStatus{
+	"available":      BikeStatus_AVAILABLE,
+	"free":           BikeStatus_AVAILABLE,
+	"in_service":     BikeStatus_AVAILABLE,
+	"completed":      BikeStatus_AVAILABLE,
+	"done":           BikeStatus_AVAILABLE,
+	"returned":       BikeStatus_AVAILABLE,
+	"rented":         BikeStatus_RENTED,
+	"ongoing":        BikeStatus_RENTED,
+	"in_use":         BikeStatus_RENTED,
+	"reserved":       BikeStatus_RESERVED,
+	"maintenance":    BikeStatus_MAINTENANCE,
+	"repair":         BikeStatus_MAINTENANCE,
+	"broken":         BikeStatus_MAINTENANCE,
+	"out_of_service": BikeStatus_MAINTENANCE,
+	"retired":        BikeStatus_RETIRED,
+	"decommissioned": BikeStatus_RETIRED,
+	"lost":           BikeStatus_LOST,
+	"stolen":         BikeStatus_LOST,
+	"missing":        BikeStatus_LOST,
+}
+
+// NormalizeBikeStatus maps a stored status to a BikeStatus. Unknown values
+// are put in MAINTENANCE so an operator looks at the bike before it is
+// rented again.
+func NormalizeBikeStatus(raw string) (BikeStatus, bool) {
+	if v, ok := BikeStatus_value[raw]; ok && v != 0 {
+		return BikeStatus(v), true
+	}
+	key := strings.ToLower(strings.TrimSpace(raw))
+	key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+	if s, ok := legacyBikeStatuses[key]; ok {
+		return s, true
+	}
+	return BikeStatus_MAINTENANCE, false
+}
+
+// MigrateBikeStatuses rewrites statuses that are not BikeStatus names,
+// marks available bikes with an ongoing rental as RENTED and returns how
+// many bikes it changed. It is safe to run on every start.
+func MigrateBikeStatuses(ctx context.Context, client *db.PrismaClient) (int, error) {
+	var canonical []string
+	for name, v := range BikeStatus_value {
+		if v != 0 {
+			canonical = append(canonical, name)
+		}
+	}
+	bikes, err := client.Bike.FindMany(
+		db.Bike.Status.NotIn(canonical),
+	).With(
+		db.Bike.Rentals.Fetch(db.Rental.EndTime.IsNull()),
+	).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, bike := range bikes {
+		to, known := NormalizeBikeStatus(bike.Status)
+		if !known {
+			log.Printf("Bike %d has unknown status %q, moving it to %s", bike.ID, bike.Status, to)
+		}
+		ongoing := len(bike.Rentals()) > 0
+		if ongoing && to != BikeStatus_LOST {
+			to = BikeStatus_RENTED
+		} else if !ongoing && to == BikeStatus_RENTED {
+			to = BikeStatus_AVAILABLE
+		}
+		_, err := client.Bike.FindMany(
+			db.Bike.ID.Equals(bike.ID),
+			db.Bike.Status.Equals(bike.Status),
+		).Update(
+			db.Bike.Status.Set(to.String()),
+		).Exec(ctx)
+		if err != nil {
+			return changed, err
+		}
+		changed++
+	}
+
+	// rentals did not use to change the bike's status at all
+	result, err := client.Bike.FindMany(
+		db.Bike.Status.Equals(BikeStatus_AVAILABLE.String()),
+		db.Bike.Rentals.Some(db.Rental.EndTime.IsNull()),
+	).Update(
+		db.Bike.Status.Set(BikeStatus_RENTED.String()),
+	).Exec(ctx)
+	if err != nil {
+		return changed, err
+	}
+	return changed + result.Count, nil
+}
@@ -0,0 +1,334 @@
+package backend
+
+import (
+	"context"
+	"db"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Booking statuses.
+const (
+	BookingConfirmed = "CONFIRMED"
+	BookingCancelled = "CANCELLED"
+)
+
+const (
+	maxBookingLength        = 7 * 24 * time.Hour
+	maxBookingAhead         = 90 * 24 * time.Hour
+	defaultAvailabilitySpan = 24 * time.Hour
+	maxAvailabilitySpan     = 31 * 24 * time.Hour
+)
+
+// BookingBuffer is how long before a booking starts the bike is kept from
+// walk-up rentals and reservations, so it is back in time. Override with
+// BOOKING_BUFFER.
+var BookingBuffer = durationFromEnv("BOOKING_BUFFER", time.Hour)
+
+// ExpectedRentalLength is how long an ongoing rental is assumed to go on
+// from now, since riders do not say when they will bring the bike back.
+// Override with EXPECTED_RENTAL_LENGTH.
+var ExpectedRentalLength = durationFromEnv("EXPECTED_RENTAL_LENGTH", 2*time.Hour)
+
+func toBooking(booking *db.BookingModel) *Booking {
+	return &Booking{
+		Id:        int32(booking.ID),
+		UserId:    int32(booking.UserID),
+		BikeId:    int32(booking.BikeID),
+		StartTime: timestamppb.New(booking.StartsAt),
+		EndTime:   timestamppb.New(booking.EndsAt),
+		Status:    booking.Status,
+		CreatedAt: timestamppb.New(booking.CreatedAt),
+	}
+}
+
+// overlappingBookings returns the confirmed bookings of bikeID that overlap
+// the range from start to end, earliest first.
+func overlappingBookings(ctx context.Context, client *db.PrismaClient, bikeID int, start, end time.Time) ([]db.BookingModel, error) {
+	return client.Booking.FindMany(
+		db.Booking.BikeID.Equals(bikeID),
+		db.Booking.Status.Equals(BookingConfirmed),
+		db.Booking.StartsAt.Lt(end),
+		db.Booking.EndsAt.Gt(start),
+	).OrderBy(
+		db.Booking.StartsAt.Order(db.SortOrderAsc),
+	).Exec(ctx)
+}
+
+// checkUpcomingBookings refuses to hand bikeID to userID until until when
+// another rider has booked it from before then, allowing for BookingBuffer.
+// Riders may take a bike they booked themselves.
+func checkUpcomingBookings(ctx context.Context, client *db.PrismaClient, bikeID int, userID int, until time.Time) error {
+	bookings, err := overlappingBookings(ctx, client, bikeID, time.Now(), until.Add(BookingBuffer))
+	if err != nil {
+		return err
+	}
+	for _, booking := range bookings {
+		if booking.UserID != userID {
+			return status.Errorf(codes.FailedPrecondition, "bike %d is booked from %s", bikeID, booking.StartsAt.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// ongoingRental returns the rental bikeID is out on, or nil.
+func ongoingRental(ctx context.Context, client *db.PrismaClient, bikeID int) (*db.RentalModel, error) {
+	rental, err := client.Rental.FindFirst(
+		db.Rental.BikeID.Equals(bikeID),
+		db.Rental.EndTime.IsNull(),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, nil
+	}
+	return rental, err
+}
+
+// unusable reports whether a bike in status s cannot be rented until staff
+// change its status, so it has no free time at all.
+func unusable(s BikeStatus) bool {
+	return s == BikeStatus_MAINTENANCE || s == BikeStatus_RETIRED || s == BikeStatus_LOST
+}
+
+// FreeSlots returns the parts of the range from start to end that none of
+// the busy slots cover. busy has to be sorted by start time.
+func FreeSlots(start, end time.Time, busy []*TimeSlot) []*TimeSlot {
+	var free []*TimeSlot
+	cursor := start
+	for _, slot := range busy {
+		if slotStart := slot.StartTime.AsTime(); slotStart.After(cursor) {
+			free = append(free, &TimeSlot{StartTime: timestamppb.New(cursor), EndTime: timestamppb.New(slotStart)})
+		}
+		if slotEnd := slot.EndTime.AsTime(); slotEnd.After(cursor) {
+			cursor = slotEnd
+		}
+	}
+	if cursor.Before(end) {
+		free = append(free, &TimeSlot{StartTime: timestamppb.New(cursor), EndTime: timestamppb.New(end)})
+	}
+	return free
+}
+
+// clip returns a slot for the part of the range from start to end that
+// lies within the range from from to to.
+func clip(start, end, from, to time.Time, kind string) *TimeSlot {
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	return &TimeSlot{StartTime: timestamppb.New(start), EndTime: timestamppb.New(end), Kind: kind}
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/bookings \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{
+	        "bike_id": 1,
+	        "start_time": "2025-06-01T09:00:00Z",
+	        "end_time": "2025-06-01T13:00:00Z"
+	      }'
+*/
+func (server *RentalServer) CreateBooking(ctx context.Context, req *CreateBookingRequest) (*Booking, error) {
+	if req.StartTime == nil || req.EndTime == nil {
+		return nil, status.Error(codes.InvalidArgument, "start_time and end_time are required")
+	}
+	start, end := req.StartTime.AsTime(), req.EndTime.AsTime()
+	now := time.Now()
+	if !end.After(start) {
+		return nil, status.Error(codes.InvalidArgument, "end_time must be after start_time")
+	}
+	if start.Before(now) {
+		return nil, status.Error(codes.InvalidArgument, "start_time must be in the future")
+	}
+	if end.Sub(start) > maxBookingLength {
+		return nil, status.Errorf(codes.InvalidArgument, "bookings can last at most %s", maxBookingLength)
+	}
+	if start.After(now.Add(maxBookingAhead)) {
+		return nil, status.Errorf(codes.InvalidArgument, "bookings can start at most %s ahead", maxBookingAhead)
+	}
+
+	email, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := server.PrismaClient.User.FindUnique(
+		db.User.Email.Equals(email),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireVerifiedEmail(user); err != nil {
+		return nil, err
+	}
+	bike, err := server.PrismaClient.Bike.FindUnique(
+		db.Bike.ID.Equals(int(req.BikeId)),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "bike %d not found", req.BikeId)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if s := bikeStatus(bike); s == BikeStatus_RETIRED || s == BikeStatus_LOST {
+		return nil, status.Errorf(codes.FailedPrecondition, "bike %d is %s", bike.ID, s)
+	}
+
+	rental, err := ongoingRental(ctx, server.PrismaClient, bike.ID)
+	if err != nil {
+		return nil, err
+	}
+	if rental != nil && start.Before(now.Add(ExpectedRentalLength)) {
+		return nil, status.Errorf(codes.FailedPrecondition, "bike %d is rented and may not be back by %s", bike.ID, start.Format(time.RFC3339))
+	}
+	overlapping, err := overlappingBookings(ctx, server.PrismaClient, bike.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlapping) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "bike %d is already booked from %s to %s", bike.ID,
+			overlapping[0].StartsAt.Format(time.RFC3339), overlapping[0].EndsAt.Format(time.RFC3339))
+	}
+	bumpVersion := server.PrismaClient.Bike.FindUnique(
+		db.Bike.IDBookingVersion(
+			db.Bike.ID.Equals(bike.ID),
+			db.Bike.BookingVersion.Equals(bike.BookingVersion),
+		),
+	).Update(
+		db.Bike.BookingVersion.Increment(1),
+	).Tx()
+	createBooking := server.PrismaClient.Booking.CreateOne(
+		db.Booking.User.Link(db.User.ID.Equals(user.ID)),
+		db.Booking.Bike.Link(db.Bike.ID.Equals(bike.ID)),
+		db.Booking.StartsAt.Set(start),
+		db.Booking.EndsAt.Set(end),
+	).Tx()
+	if err := server.PrismaClient.Prisma.Transaction(bumpVersion, createBooking).Exec(ctx); err != nil {
+		if current, findErr := server.PrismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Exec(ctx); findErr == nil && current.BookingVersion != bike.BookingVersion {
+			return nil, status.Errorf(codes.Aborted, "bike %d was booked concurrently, try again", bike.ID)
+		}
+		log.Printf("Could not book bike %d: %v", bike.ID, err)
+		return nil, status.Error(codes.Internal, "could not create booking")
+	}
+	return toBooking(createBooking.Result()), nil
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/bookings/1/cancel \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{}'
+*/
+func (server *RentalServer) CancelBooking(ctx context.Context, req *CancelBookingRequest) (*Booking, error) {
+	email, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	booking, err := server.PrismaClient.Booking.FindUnique(
+		db.Booking.ID.Equals(int(req.Id)),
+	).With(
+		db.Booking.User.Fetch(),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "booking %d not found", req.Id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !IsStaff(CurrentRole(ctx)) && booking.User().Email != email {
+		return nil, status.Error(codes.PermissionDenied, "booking belongs to another user")
+	}
+	if booking.Status != BookingConfirmed {
+		return nil, status.Errorf(codes.FailedPrecondition, "booking %d is %s", booking.ID, booking.Status)
+	}
+	if booking.EndsAt.Before(time.Now()) {
+		return nil, status.Errorf(codes.FailedPrecondition, "booking %d has ended", booking.ID)
+	}
+
+	result, err := server.PrismaClient.Booking.FindMany(
+		db.Booking.ID.Equals(booking.ID),
+		db.Booking.Status.Equals(BookingConfirmed),
+	).Update(
+		db.Booking.Status.Set(BookingCancelled),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if result.Count == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "booking %d was cancelled already", booking.ID)
+	}
+	booking.Status = BookingCancelled
+	return toBooking(booking), nil
+}
+
+/*
+	curl -X GET 'http://localhost:8080/v1/bikes/1/availability?start_time=2025-06-01T00:00:00Z&end_time=2025-06-02T00:00:00Z' \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *BikeServer) GetBikeAvailability(ctx context.Context, req *GetBikeAvailabilityRequest) (*BikeAvailability, error) {
+	start := time.Now()
+	if req.StartTime != nil {
+		start = req.StartTime.AsTime()
+	}
+	end := start.Add(defaultAvailabilitySpan)
+	if req.EndTime != nil {
+		end = req.EndTime.AsTime()
+	}
+	if !end.After(start) {
+		return nil, status.Error(codes.InvalidArgument, "end_time must be after start_time")
+	}
+	if end.Sub(start) > maxAvailabilitySpan {
+		return nil, status.Errorf(codes.InvalidArgument, "the range can span at most %s", maxAvailabilitySpan)
+	}
+	bike, err := server.PrismaClient.Bike.FindUnique(
+		db.Bike.ID.Equals(int(req.BikeId)),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "bike %d not found", req.BikeId)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bookings, err := overlappingBookings(ctx, server.PrismaClient, bike.ID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	var busy []*TimeSlot
+	for _, booking := range bookings {
+		busy = append(busy, clip(booking.StartsAt, booking.EndsAt, start, end, "BOOKING"))
+	}
+	reservation, err := activeReservation(ctx, server.PrismaClient, bike.ID)
+	if err != nil {
+		return nil, err
+	}
+	if reservation != nil && reservation.ExpiresAt.After(start) && reservation.CreatedAt.Before(end) {
+		busy = append(busy, clip(reservation.CreatedAt, reservation.ExpiresAt, start, end, "RESERVATION"))
+	}
+	rental, err := ongoingRental(ctx, server.PrismaClient, bike.ID)
+	if err != nil {
+		return nil, err
+	}
+	if back := time.Now().Add(ExpectedRentalLength); rental != nil && back.After(start) && rental.StartTime.Before(end) {
+		busy = append(busy, clip(rental.StartTime, back, start, end, "RENTAL"))
+	}
+	sort.SliceStable(busy, func(i, j int) bool {
+		return busy[i].StartTime.AsTime().Before(busy[j].StartTime.AsTime())
+	})
+	availability := &BikeAvailability{
+		BikeId: int32(bike.ID),
+		Status: bikeStatus(bike),
+		Busy:   busy,
+	}
+	if !unusable(availability.Status) {
+		availability.Free = FreeSlots(start, end, busy)
+	}
+	return availability, nil
+}
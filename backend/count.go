@@ -0,0 +1,24 @@
+package backend
+
+import (
+	"context"
+	"db"
+	"encoding/json"
+)
+
+// countRows runs query, a SELECT COUNT(*) AS "count", through a raw query,
+// since the client cannot count without loading the rows.
+func countRows(ctx context.Context, client *db.PrismaClient, query string, args ...interface{}) (int, error) {
+	// SQLite returns the count as a string, other databases as a number
+	var rows []struct {
+		Count json.Number `json:"count"`
+	}
+	if err := client.Prisma.QueryRaw(query, args...).Exec(ctx, &rows); err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	count, err := rows[0].Count.Int64()
+	return int(count), err
+}
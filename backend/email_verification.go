@@ -0,0 +1,154 @@
+package backend
+
+import (
+	"context"
+	"db"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// EmailVerificationTTL is how long a verification token stays valid.
+// Override with EMAIL_VERIFICATION_TTL.
+var EmailVerificationTTL = durationFromEnv("EMAIL_VERIFICATION_TTL", 48*time.Hour)
+
+// RequireVerifiedEmail makes CreateRental refuse accounts that have not
+// verified their email address yet. Login works either way. Override with
+// REQUIRE_EMAIL_VERIFICATION.
+var RequireVerifiedEmail = boolFromEnv("REQUIRE_EMAIL_VERIFICATION", true)
+
+var ErrEmailVerificationInvalid = errors.New("invalid or expired email verification token")
+
+// verificationResendInterval keeps ResendVerificationEmail from being used
+// to flood a mailbox.
+const verificationResendInterval = time.Minute
+
+// issueVerificationEmail issues a new verification token for user and
+// mails it.
+func (s *AuthenticatorServer) issueVerificationEmail(ctx context.Context, user *db.UserModel) error {
+	token, hash := newRefreshToken()
+	_, err := s.PrismaClient.EmailVerificationToken.CreateOne(
+		db.EmailVerificationToken.TokenHash.Set(hash),
+		db.EmailVerificationToken.User.Link(db.User.ID.Equals(user.ID)),
+		db.EmailVerificationToken.ExpiresAt.Set(time.Now().Add(EmailVerificationTTL)),
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("could not store email verification token: %w", err)
+	}
+	if err := s.mailer().Send(ctx, verificationMail(user.Email, token)); err != nil {
+		return fmt.Errorf("could not send verification mail: %w", err)
+	}
+	return nil
+}
+
+// sendVerificationEmail is issueVerificationEmail for Register. Failures
+// are only logged so registration is never blocked by mail; the user can
+// ask for another mail with ResendVerificationEmail.
+func (s *AuthenticatorServer) sendVerificationEmail(ctx context.Context, user *db.UserModel) {
+	if err := s.issueVerificationEmail(ctx, user); err != nil {
+		log.Printf("Verification mail for user %d: %v", user.ID, err)
+	}
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/auth/verify-email/resend \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{}'
+*/
+func (s *AuthenticatorServer) ResendVerificationEmail(ctx context.Context, in *ResendVerificationEmailRequest) (*ResendVerificationEmailReply, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.EmailVerified {
+		return nil, status.Error(codes.FailedPrecondition, "your email address is already verified")
+	}
+	recent, err := s.PrismaClient.EmailVerificationToken.FindMany(
+		db.EmailVerificationToken.UserID.Equals(user.ID),
+		db.EmailVerificationToken.CreatedAt.Gt(time.Now().Add(-verificationResendInterval)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(recent) > 0 {
+		return nil, status.Errorf(codes.ResourceExhausted, "a verification mail was sent less than %s ago", verificationResendInterval)
+	}
+	if err := s.issueVerificationEmail(ctx, user); err != nil {
+		log.Printf("Verification mail for user %d: %v", user.ID, err)
+		return nil, status.Error(codes.Unavailable, "could not send the verification mail, try again later")
+	}
+	return &ResendVerificationEmailReply{
+		Reply: "Verification mail sent",
+	}, nil
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/auth/verify-email \
+	  -H 'Content-Type: application/json' \
+	  -d '{
+	        "token": "$VERIFICATION_TOKEN"
+	      }'
+*/
+func (s *AuthenticatorServer) VerifyEmail(ctx context.Context, in *VerifyEmailRequest) (*VerifyEmailReply, error) {
+	stored, err := s.PrismaClient.EmailVerificationToken.FindUnique(
+		db.EmailVerificationToken.TokenHash.Equals(hashToken(in.Token)),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.InvalidArgument, ErrEmailVerificationInvalid.Error())
+	}
+	if err != nil {
+		log.Printf("Could not look up email verification token: %v", err)
+		return nil, status.Error(codes.Internal, "could not verify email")
+	}
+	if _, used := stored.UsedAt(); used || time.Now().After(stored.ExpiresAt) {
+		return nil, status.Error(codes.InvalidArgument, ErrEmailVerificationInvalid.Error())
+	}
+
+	markUsed := s.PrismaClient.EmailVerificationToken.FindMany(
+		db.EmailVerificationToken.UserID.Equals(stored.UserID),
+		db.EmailVerificationToken.UsedAt.IsNull(),
+	).Update(
+		db.EmailVerificationToken.UsedAt.Set(time.Now()),
+	).Tx()
+	verify := s.PrismaClient.User.FindUnique(
+		db.User.ID.Equals(stored.UserID),
+	).Update(
+		db.User.EmailVerified.Set(true),
+	).Tx()
+	if err := s.PrismaClient.Prisma.Transaction(markUsed, verify).Exec(ctx); err != nil {
+		log.Printf("Could not verify email of user %d: %v", stored.UserID, err)
+		return nil, status.Error(codes.Internal, "could not verify email")
+	}
+	return &VerifyEmailReply{
+		Reply: "Email address verified",
+	}, nil
+}
+
+// requireVerifiedEmail enforces RequireVerifiedEmail for user.
+func requireVerifiedEmail(user *db.UserModel) error {
+	if RequireVerifiedEmail && !user.EmailVerified {
+		return status.Error(codes.FailedPrecondition, "verify your email address first")
+	}
+	return nil
+}
+
+// verificationMail links to EMAIL_VERIFICATION_URL when it is set,
+// otherwise the token is sent on its own.
+func verificationMail(to string, token string) *Mail {
+	body := fmt.Sprintf("Use this token to verify your BikeRental email address:\n\n%s\n", token)
+	if url := os.Getenv("EMAIL_VERIFICATION_URL"); url != "" {
+		body = fmt.Sprintf("Open this link to verify your BikeRental email address:\n\n%s?token=%s\n", url, token)
+	}
+	body += fmt.Sprintf("\nIt expires in %s.\n", EmailVerificationTTL)
+	return &Mail{
+		To:      to,
+		Subject: "Verify your BikeRental email address",
+		Body:    body,
+	}
+}
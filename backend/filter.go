@@ -0,0 +1,129 @@
+package backend
+
+import (
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FilterTerm is one comparison of an AIP-160 filter, such as
+// status = AVAILABLE.
+type FilterTerm struct {
+	Field string
+	Op    string
+	Value string
+}
+
+// filterOps lists the comparison operators, two-character ones first so
+// they win over their prefixes.
+var filterOps = []string{"<=", ">=", "!=", "=", "<", ">", ":"}
+
+// ParseFilter splits an AIP-160 filter into its comparisons. Only the
+// subset our list RPCs need is supported: comparisons of a field with a
+// bare or double-quoted value, joined with AND. OR, NOT and parentheses
+// are rejected rather than silently misread.
+func ParseFilter(filter string) ([]FilterTerm, error) {
+	var terms []FilterTerm
+	rest := strings.TrimSpace(filter)
+	for rest != "" {
+		var term FilterTerm
+		end := strings.IndexFunc(rest, func(r rune) bool {
+			return !(r == '_' || r == '.' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+		})
+		if end == -1 {
+			end = len(rest)
+		}
+		term.Field, rest = rest[:end], strings.TrimLeft(rest[end:], " ")
+		if term.Field == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "filter: expected a field at %q", rest)
+		}
+		if term.Field == "OR" || term.Field == "NOT" {
+			return nil, status.Errorf(codes.InvalidArgument, "filter: only AND is supported")
+		}
+		for _, op := range filterOps {
+			if strings.HasPrefix(rest, op) {
+				term.Op, rest = op, strings.TrimLeft(rest[len(op):], " ")
+				break
+			}
+		}
+		if term.Op == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "filter: expected an operator after %s", term.Field)
+		}
+
+		if strings.HasPrefix(rest, `"`) {
+			var value strings.Builder
+			i := 1
+			for ; i < len(rest) && rest[i] != '"'; i++ {
+				if rest[i] == '\\' && i+1 < len(rest) {
+					i++
+				}
+				value.WriteByte(rest[i])
+			}
+			if i == len(rest) {
+				return nil, status.Error(codes.InvalidArgument, "filter: unterminated string")
+			}
+			term.Value, rest = value.String(), rest[i+1:]
+		} else {
+			end := strings.IndexByte(rest, ' ')
+			if end == -1 {
+				end = len(rest)
+			}
+			term.Value, rest = rest[:end], rest[end:]
+			if term.Value == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "filter: expected a value for %s", term.Field)
+			}
+		}
+		terms = append(terms, term)
+
+		rest = strings.TrimLeft(rest, " ")
+		if rest == "" {
+			break
+		}
+		if !strings.HasPrefix(rest, "AND ") {
+			return nil, status.Errorf(codes.InvalidArgument, "filter: expected AND at %q, only AND is supported", rest)
+		}
+		rest = strings.TrimLeft(rest[len("AND "):], " ")
+		if rest == "" {
+			return nil, status.Error(codes.InvalidArgument, "filter: expected a comparison after AND")
+		}
+	}
+	return terms, nil
+}
+
+// filterTime reads the RFC 3339 timestamp a term compares with.
+func filterTime(term FilterTerm) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, term.Value)
+	if err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "filter: %s needs an RFC 3339 timestamp, got %q", term.Field, term.Value)
+	}
+	return t, nil
+}
+
+// OrderField is one field of an AIP-132 order_by.
+type OrderField struct {
+	Field string
+	Desc  bool
+}
+
+// ParseOrderBy splits an AIP-132 order_by such as "create_time desc, id"
+// into its fields.
+func ParseOrderBy(orderBy string) ([]OrderField, error) {
+	var fields []OrderField
+	if strings.TrimSpace(orderBy) == "" {
+		return nil, nil
+	}
+	for _, part := range strings.Split(orderBy, ",") {
+		words := strings.Fields(part)
+		switch {
+		case len(words) == 1:
+			fields = append(fields, OrderField{Field: words[0]})
+		case len(words) == 2 && (words[1] == "asc" || words[1] == "desc"):
+			fields = append(fields, OrderField{Field: words[0], Desc: words[1] == "desc"})
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "order_by: cannot read %q", strings.TrimSpace(part))
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,106 @@
+package backend
+
+import (
+	"math"
+	"strings"
+)
+
+const (
+	geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+	// stationGeohashPrecision cells are about 5 m wide, more than precise
+	// enough to find every station in a cell by prefix.
+	stationGeohashPrecision = 9
+	earthRadiusMeters       = 6371000
+	metersPerDegree         = 2 * math.Pi * earthRadiusMeters / 360
+)
+
+// Geohash encodes a point as a geohash of precision characters. Points
+// sharing a prefix lie in the same cell.
+func Geohash(latitude, longitude float64, precision int) string {
+	latRange := [2]float64{-90, 90}
+	lonRange := [2]float64{-180, 180}
+	var hash strings.Builder
+	even := true
+	bit, ch := 0, 0
+	for hash.Len() < precision {
+		r, v := &latRange, latitude
+		if even {
+			r, v = &lonRange, longitude
+		}
+		mid := (r[0] + r[1]) / 2
+		ch <<= 1
+		if v >= mid {
+			ch |= 1
+			r[0] = mid
+		} else {
+			r[1] = mid
+		}
+		even = !even
+		if bit++; bit == 5 {
+			hash.WriteByte(geohashAlphabet[ch])
+			bit, ch = 0, 0
+		}
+	}
+	return hash.String()
+}
+
+// geohashCellSize returns the size in degrees of a cell of precision
+// characters.
+func geohashCellSize(precision int) (latDegrees, lonDegrees float64) {
+	bits := 5 * precision
+	lonBits := (bits + 1) / 2
+	latBits := bits / 2
+	return 180 / math.Pow(2, float64(latBits)), 360 / math.Pow(2, float64(lonBits))
+}
+
+// NearbyCells returns the cell containing the point and its neighbours,
+// using the longest precision whose cells are at least radius wide so the
+// nine cells cover every point within radius. It returns nil when no
+// precision is coarse enough, near the poles for instance, and the caller
+// has to look at everything.
+func NearbyCells(latitude, longitude, radiusMeters float64) []string {
+	precision := 0
+	for p := stationGeohashPrecision; p >= 1; p-- {
+		latDegrees, lonDegrees := geohashCellSize(p)
+		height := latDegrees * metersPerDegree
+		// cells narrow towards the poles, measure the row furthest out
+		edge := math.Min(90, math.Abs(latitude)+latDegrees)
+		width := lonDegrees * metersPerDegree * math.Cos(edge*math.Pi/180)
+		if height >= radiusMeters && width >= radiusMeters {
+			precision = p
+			break
+		}
+	}
+	if precision == 0 {
+		return nil
+	}
+
+	latDegrees, lonDegrees := geohashCellSize(precision)
+	seen := map[string]bool{}
+	var cells []string
+	for _, dLat := range []float64{-latDegrees, 0, latDegrees} {
+		lat := latitude + dLat
+		if lat > 90 || lat < -90 {
+			continue
+		}
+		for _, dLon := range []float64{-lonDegrees, 0, lonDegrees} {
+			lon := math.Mod(longitude+dLon+540, 360) - 180
+			cell := Geohash(lat, lon, precision)
+			if !seen[cell] {
+				seen[cell] = true
+				cells = append(cells, cell)
+			}
+		}
+	}
+	return cells
+}
+
+// DistanceMeters returns the great-circle distance between two points.
+func DistanceMeters(lat1, lon1, lat2, lon2 float64) float64 {
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLon := (lon2 - lon1) * toRad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(a)))
+}
@@ -1,33 +1,117 @@
 package backend
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var jwtSecret = []byte("your-256-bit-secret")
+var (
+	keysMu sync.Mutex
+	keys   *KeySet
+)
+
+// Keys returns the KeySet used by GenerateJWT and VerifyJWT, loading it
+// with LoadKeySetFromEnv on first use. The server sets its keys with
+// SetKeys on start, so only tests and tools get here without one; they
+// fall back to an ephemeral key.
+func Keys() *KeySet {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+	if keys == nil {
+		loaded, err := LoadKeySetFromEnv()
+		if errors.Is(err, ErrNoSigningKeys) {
+			loaded, err = NewEd25519KeySet(), nil
+		}
+		if err != nil {
+			panic(fmt.Errorf("could not load JWT keys: %w", err))
+		}
+		keys = loaded
+	}
+	return keys
+}
+
+// SetKeys replaces the KeySet used by GenerateJWT and VerifyJWT.
+func SetKeys(set *KeySet) {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+	keys = set
+}
+
+// Issuer and Audience are written into every token and required when
+// verifying one. Override with JWT_ISSUER and JWT_AUDIENCE.
+var (
+	Issuer   = stringFromEnv("JWT_ISSUER", "bikerental")
+	Audience = stringFromEnv("JWT_AUDIENCE", "bikerental-api")
+)
+
+// AccessTokenTTL is how long tokens from GenerateJWT stay valid. Clients
+// renew them through the Refresh RPC. Override with ACCESS_TOKEN_TTL.
+var AccessTokenTTL = durationFromEnv("ACCESS_TOKEN_TTL", 15*time.Minute)
+
+// Authentication methods recorded in the amr claim, see RFC 8176.
+const (
+	AMRPassword = "pwd"
+	AMROTP      = "otp"
+)
 
 type Claims struct {
 	Email string `json:"email"`
+	Role  string `json:"role"`
+	// AMR lists how the user authenticated.
+	AMR []string `json:"amr,omitempty"`
+	// SessionID is the Session the token was issued for.
+	SessionID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func NewClaims(email string) *Claims {
-	expirationTime := time.Now().Add(24 * time.Hour)
+// MFA reports whether the token was issued after a second factor was
+// verified.
+func (c *Claims) MFA() bool {
+	for _, m := range c.AMR {
+		if m == AMROTP {
+			return true
+		}
+	}
+	return false
+}
+
+func NewClaims(email string, role string) *Claims {
+	now := time.Now()
 	return &Claims{
 		Email: email,
+		Role:  role,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			ID:        newTokenID(),
+			Issuer:    Issuer,
+			Audience:  jwt.ClaimStrings{Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL)),
 		},
 	}
 }
 
-func GenerateJWT(email string) (string, error) {
-	claims := NewClaims(email)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(jwtSecret)
+// GenerateJWT issues an access token. amr lists the authentication
+// methods used, see AMRPassword and AMROTP.
+func GenerateJWT(email string, role string, amr ...string) (string, error) {
+	return GenerateSessionJWT("", email, role, amr...)
+}
+
+// GenerateSessionJWT issues an access token bound to sessionID. It stops
+// working as soon as the session is revoked.
+func GenerateSessionJWT(sessionID string, email string, role string, amr ...string) (string, error) {
+	claims := NewClaims(email, role)
+	claims.AMR = amr
+	claims.SessionID = sessionID
+	tokenString, err := Keys().Sign(claims)
 	if err != nil {
 		return "", err
 	}
@@ -36,12 +120,50 @@ func GenerateJWT(email string) (string, error) {
 
 func VerifyJWT(tokenStr string) (*Claims, error) {
 	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-		return jwtSecret, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenStr, claims, Keys().Keyfunc,
+		jwt.WithIssuer(Issuer),
+		jwt.WithAudience(Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(30*time.Second),
+	)
 
 	if err != nil || !token.Valid {
 		return nil, fmt.Errorf("invalid token: %v", err)
 	}
+	// tokens issued before roles existed belong to riders
+	if claims.Role == "" {
+		claims.Role = RoleRider
+	}
 	return claims, nil
 }
+
+// newTokenID returns a random identifier used as the jti claim.
+func newTokenID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func stringFromEnv(name string, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func boolFromEnv(name string, fallback bool) bool {
+	if b, err := strconv.ParseBool(os.Getenv(name)); err == nil {
+		return b
+	}
+	return fallback
+}
+
+func durationFromEnv(name string, fallback time.Duration) time.Duration {
+	if d, err := time.ParseDuration(os.Getenv(name)); err == nil && d > 0 {
+		return d
+	}
+	return fallback
+}
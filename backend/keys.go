@@ -0,0 +1,368 @@
+package backend
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningKey is one entry of a KeySet, identified by its kid header.
+// Keys without a private part can only verify tokens.
+type SigningKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.PrivateKey
+	Public  crypto.PublicKey
+}
+
+func (k *SigningKey) canSign() bool {
+	return k.Private != nil
+}
+
+// KeySet holds the keys used to sign and verify access tokens. Several keys
+// can be active at once so keys can be rotated without downtime: publish
+// the new key to every replica first, switch JWT_SIGNING_KEY_ID to it, and
+// remove the old key once tokens signed with it have expired.
+type KeySet struct {
+	mu      sync.RWMutex
+	keys    map[string]*SigningKey
+	current string
+
+	// load re-reads the keys from their source, nil for static sets
+	load       func() (map[string]*SigningKey, string, error)
+	lastReload time.Time
+}
+
+// NewKeySet returns a static KeySet signing with the key identified by
+// current.
+func NewKeySet(current string, keys ...*SigningKey) (*KeySet, error) {
+	set := &KeySet{keys: map[string]*SigningKey{}}
+	for _, k := range keys {
+		set.keys[k.ID] = k
+	}
+	if err := set.setCurrent(current); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// NewEd25519KeySet generates a fresh Ed25519 key. Tokens signed with it
+// cannot be verified by other processes, so it is only useful for tests and
+// local development.
+func NewEd25519KeySet() *KeySet {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	key := &SigningKey{
+		ID:      "dev-" + newTokenID()[:8],
+		Method:  jwt.SigningMethodEdDSA,
+		Private: private,
+		Public:  public,
+	}
+	set, _ := NewKeySet(key.ID, key)
+	return set
+}
+
+// ErrNoSigningKeys is returned by LoadKeySetFromEnv when no key is
+// configured.
+var ErrNoSigningKeys = errors.New("no JWT signing keys configured, set JWT_KEYS_DIR, JWT_PRIVATE_KEY or JWT_SECRET, or JWT_EPHEMERAL_KEY=true for development")
+
+// LoadKeySetFromEnv builds the KeySet from the environment:
+//
+//   - JWT_KEYS_DIR: directory of PEM files named <kid>.pem. RSA keys sign
+//     with RS256 and Ed25519 keys with EdDSA. Files holding only a public
+//     key are used for verification.
+//   - JWT_PRIVATE_KEY and JWT_KEY_ID: a single PEM encoded private key.
+//   - JWT_SECRET: a shared HS256 secret, kept for simple setups.
+//   - JWT_SIGNING_KEY_ID: kid used for new tokens, defaults to the last
+//     private key in lexical order.
+//
+// Without any of them it fails with ErrNoSigningKeys, unless
+// JWT_EPHEMERAL_KEY is true for development, which generates an ephemeral
+// Ed25519 key.
+func LoadKeySetFromEnv() (*KeySet, error) {
+	load := func() (map[string]*SigningKey, string, error) {
+		keys := map[string]*SigningKey{}
+		if dir := os.Getenv("JWT_KEYS_DIR"); dir != "" {
+			files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+			if err != nil {
+				return nil, "", err
+			}
+			for _, file := range files {
+				data, err := os.ReadFile(file)
+				if err != nil {
+					return nil, "", err
+				}
+				id := strings.TrimSuffix(filepath.Base(file), ".pem")
+				key, err := ParseSigningKey(id, data)
+				if err != nil {
+					return nil, "", fmt.Errorf("%s: %w", file, err)
+				}
+				keys[id] = key
+			}
+		}
+		if data := os.Getenv("JWT_PRIVATE_KEY"); data != "" {
+			id := os.Getenv("JWT_KEY_ID")
+			if id == "" {
+				id = "default"
+			}
+			key, err := ParseSigningKey(id, []byte(data))
+			if err != nil {
+				return nil, "", fmt.Errorf("JWT_PRIVATE_KEY: %w", err)
+			}
+			keys[id] = key
+		}
+		if secret := os.Getenv("JWT_SECRET"); secret != "" {
+			id := os.Getenv("JWT_SECRET_KEY_ID")
+			if id == "" {
+				id = "hs256"
+			}
+			keys[id] = &SigningKey{
+				ID:      id,
+				Method:  jwt.SigningMethodHS256,
+				Private: []byte(secret),
+				Public:  []byte(secret),
+			}
+		}
+		return keys, os.Getenv("JWT_SIGNING_KEY_ID"), nil
+	}
+
+	keys, current, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		// every replica would sign with a key of its own and reject the
+		// tokens of the others, so only allow this when asked for
+		if !boolFromEnv("JWT_EPHEMERAL_KEY", false) {
+			return nil, ErrNoSigningKeys
+		}
+		log.Println("WARNING: no JWT signing keys configured, using an ephemeral key")
+		return NewEd25519KeySet(), nil
+	}
+	set := &KeySet{keys: keys, load: load}
+	if err := set.setCurrent(current); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
+
+// ParseSigningKey parses a PEM encoded RSA or Ed25519 key, either private
+// (PKCS#1 or PKCS#8) or public (PKIX).
+func ParseSigningKey(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{ID: id}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.Private, key.Public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.Private, key.Public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Method, key.Public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+	return key, nil
+}
+
+// setCurrent picks the signing key, falling back to the last private key
+// by kid when current is empty. Callers must hold the write lock or own
+// the set exclusively.
+func (s *KeySet) setCurrent(current string) error {
+	if current == "" {
+		var ids []string
+		for id, k := range s.keys {
+			if k.canSign() {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) == 0 {
+			return fmt.Errorf("no private JWT signing key configured")
+		}
+		sort.Strings(ids)
+		current = ids[len(ids)-1]
+	}
+	key, ok := s.keys[current]
+	if !ok || !key.canSign() {
+		return fmt.Errorf("signing key %q not found or has no private key", current)
+	}
+	s.current = current
+	return nil
+}
+
+// Reload re-reads keys from their source. Static key sets are unaffected.
+func (s *KeySet) Reload() error {
+	if s.load == nil {
+		return nil
+	}
+	keys, current, err := s.load()
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.keys
+	s.keys = keys
+	if err := s.setCurrent(current); err != nil {
+		s.keys = previous
+		return err
+	}
+	s.lastReload = time.Now()
+	return nil
+}
+
+// Sign signs claims with the current signing key and sets the kid header.
+func (s *KeySet) Sign(claims jwt.Claims) (string, error) {
+	s.mu.RLock()
+	key := s.keys[s.current]
+	s.mu.RUnlock()
+
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
+}
+
+// Keyfunc resolves the verification key for token by its kid header and
+// makes sure the token uses that key's algorithm. An unknown kid triggers
+// a reload, at most once a minute, so keys published to another replica
+// first are picked up without waiting for the next scheduled reload.
+func (s *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key := s.lookup(kid)
+	if key == nil && s.load != nil {
+		s.mu.RLock()
+		stale := time.Since(s.lastReload) > time.Minute
+		s.mu.RUnlock()
+		if stale {
+			if err := s.Reload(); err != nil {
+				log.Printf("Failed to reload JWT keys: %v", err)
+			}
+			key = s.lookup(kid)
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+	}
+	return key.Public, nil
+}
+
+func (s *KeySet) lookup(kid string) *SigningKey {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if kid == "" {
+		// tokens without kid can only come from a single shared secret
+		for _, k := range s.keys {
+			if k.Method == jwt.SigningMethodHS256 {
+				return k
+			}
+		}
+		return nil
+	}
+	return s.keys[kid]
+}
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS returns the public keys as a JSON Web Key Set. Shared HMAC secrets
+// are never published.
+func (s *KeySet) JWKS() ([]byte, error) {
+	s.mu.RLock()
+	var ids []string
+	for id := range s.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	keys := []jwk{}
+	for _, id := range ids {
+		k := s.keys[id]
+		switch public := k.Public.(type) {
+		case *rsa.PublicKey:
+			keys = append(keys, jwk{
+				Kty: "RSA",
+				Kid: k.ID,
+				Use: "sig",
+				Alg: k.Method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			keys = append(keys, jwk{
+				Kty: "OKP",
+				Kid: k.ID,
+				Use: "sig",
+				Alg: k.Method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(public),
+			})
+		}
+	}
+	s.mu.RUnlock()
+	return json.Marshal(map[string][]jwk{"keys": keys})
+}
+
+// ServeJWKS serves JWKS. Its signature matches runtime.HandlerFunc so it
+// can be mounted on the gateway mux with HandlePath.
+func (s *KeySet) ServeJWKS(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	data, err := s.JWKS()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Write(data)
+}
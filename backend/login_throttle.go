@@ -0,0 +1,331 @@
+package backend
+
+import (
+	"context"
+	"db"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// LoginAttempts is the failed login history of one email address or IP.
+type LoginAttempts struct {
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+// AttemptStore keeps LoginAttempts by key. MemoryAttemptStore works for a
+// single replica; use PrismaAttemptStore when several replicas have to
+// share the counters.
+type AttemptStore interface {
+	Get(ctx context.Context, key string) (LoginAttempts, error)
+	// RecordFailure counts a failed attempt at now and returns the new
+	// count. Failures older than window are forgotten first.
+	RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (int, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+	// Purge drops entries whose last failure is before cutoff and that are
+	// not locked anymore.
+	Purge(ctx context.Context, cutoff time.Time) error
+}
+
+type MemoryAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]*LoginAttempts
+}
+
+func NewMemoryAttemptStore() *MemoryAttemptStore {
+	return &MemoryAttemptStore{attempts: map[string]*LoginAttempts{}}
+}
+
+func (s *MemoryAttemptStore) Get(ctx context.Context, key string) (LoginAttempts, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.attempts[key]; ok {
+		return *a, nil
+	}
+	return LoginAttempts{}, nil
+}
+
+func (s *MemoryAttemptStore) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.attempts[key]
+	if !ok {
+		a = &LoginAttempts{}
+		s.attempts[key] = a
+	}
+	if now.Sub(a.LastFailure) > window {
+		a.Failures = 0
+	}
+	a.Failures++
+	a.LastFailure = now
+	return a.Failures, nil
+}
+
+func (s *MemoryAttemptStore) Lock(ctx context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if a, ok := s.attempts[key]; ok {
+		a.LockedUntil = until
+	}
+	return nil
+}
+
+func (s *MemoryAttemptStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.attempts, key)
+	return nil
+}
+
+func (s *MemoryAttemptStore) Purge(ctx context.Context, cutoff time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, a := range s.attempts {
+		if a.LastFailure.Before(cutoff) && a.LockedUntil.Before(cutoff) {
+			delete(s.attempts, key)
+		}
+	}
+	return nil
+}
+
+// PrismaAttemptStore persists LoginAttempts in the LoginAttempt table.
+type PrismaAttemptStore struct {
+	PrismaClient *db.PrismaClient
+}
+
+func (s *PrismaAttemptStore) Get(ctx context.Context, key string) (LoginAttempts, error) {
+	row, err := s.PrismaClient.LoginAttempt.FindUnique(
+		db.LoginAttempt.Key.Equals(key),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return LoginAttempts{}, nil
+	}
+	if err != nil {
+		return LoginAttempts{}, err
+	}
+	lockedUntil, _ := row.LockedUntil()
+	return LoginAttempts{
+		Failures:    row.Failures,
+		LastFailure: row.LastFailure,
+		LockedUntil: lockedUntil,
+	}, nil
+}
+
+func (s *PrismaAttemptStore) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (int, error) {
+	current, err := s.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	// the increment itself is atomic, so concurrent failures on several
+	// replicas are all counted
+	update := db.LoginAttempt.Failures.Increment(1)
+	if current.Failures > 0 && now.Sub(current.LastFailure) > window {
+		update = db.LoginAttempt.Failures.Set(1)
+	}
+	row, err := s.PrismaClient.LoginAttempt.UpsertOne(
+		db.LoginAttempt.Key.Equals(key),
+	).Create(
+		db.LoginAttempt.Key.Set(key),
+		db.LoginAttempt.LastFailure.Set(now),
+		db.LoginAttempt.Failures.Set(1),
+	).Update(
+		update,
+		db.LoginAttempt.LastFailure.Set(now),
+	).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return row.Failures, nil
+}
+
+func (s *PrismaAttemptStore) Lock(ctx context.Context, key string, until time.Time) error {
+	_, err := s.PrismaClient.LoginAttempt.FindMany(
+		db.LoginAttempt.Key.Equals(key),
+	).Update(
+		db.LoginAttempt.LockedUntil.Set(until),
+	).Exec(ctx)
+	return err
+}
+
+func (s *PrismaAttemptStore) Reset(ctx context.Context, key string) error {
+	_, err := s.PrismaClient.LoginAttempt.FindMany(
+		db.LoginAttempt.Key.Equals(key),
+	).Delete().Exec(ctx)
+	return err
+}
+
+func (s *PrismaAttemptStore) Purge(ctx context.Context, cutoff time.Time) error {
+	_, err := s.PrismaClient.LoginAttempt.FindMany(
+		db.LoginAttempt.LastFailure.Lt(cutoff),
+		db.LoginAttempt.Or(
+			db.LoginAttempt.LockedUntil.IsNull(),
+			db.LoginAttempt.LockedUntil.Lt(cutoff),
+		),
+	).Delete().Exec(ctx)
+	return err
+}
+
+// LoginThrottle slows down password guessing. Every failed login counts
+// against both the email address and the client IP. From the second
+// failure on, further attempts on the account are refused for BaseDelay,
+// doubling with each failure, and after MaxFailures the account is locked
+// for LockoutDuration. The IP is locked for LockoutDuration once it reaches
+// MaxFailuresPerIP, without backing off before. An admin can lift the lock early with
+// UnlockAccount.
+type LoginThrottle struct {
+	Store            AttemptStore
+	MaxFailures      int
+	MaxFailuresPerIP int
+	BaseDelay        time.Duration
+	LockoutDuration  time.Duration
+	// Window is how long a failure is remembered.
+	Window time.Duration
+}
+
+func NewLoginThrottle(store AttemptStore) *LoginThrottle {
+	return &LoginThrottle{
+		Store:       store,
+		MaxFailures: 5,
+		// many riders can share an address behind NAT
+		MaxFailuresPerIP: 50,
+		BaseDelay:        time.Second,
+		LockoutDuration:  15 * time.Minute,
+		Window:           time.Hour,
+	}
+}
+
+// NewLoginThrottleFromEnv keeps the counters in memory unless
+// LOGIN_ATTEMPT_STORE is "database". LOGIN_MAX_FAILURES,
+// LOGIN_MAX_FAILURES_PER_IP and LOGIN_LOCKOUT_DURATION tune the limits.
+func NewLoginThrottleFromEnv(client *db.PrismaClient) *LoginThrottle {
+	var store AttemptStore = NewMemoryAttemptStore()
+	if strings.EqualFold(os.Getenv("LOGIN_ATTEMPT_STORE"), "database") {
+		store = &PrismaAttemptStore{PrismaClient: client}
+	}
+	t := NewLoginThrottle(store)
+	if v, err := strconv.Atoi(os.Getenv("LOGIN_MAX_FAILURES")); err == nil && v > 0 {
+		t.MaxFailures = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("LOGIN_MAX_FAILURES_PER_IP")); err == nil && v > 0 {
+		t.MaxFailuresPerIP = v
+	}
+	t.LockoutDuration = durationFromEnv("LOGIN_LOCKOUT_DURATION", t.LockoutDuration)
+	return t
+}
+
+func emailAttemptKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipAttemptKey(ip string) string {
+	return "ip:" + ip
+}
+
+func (t *LoginThrottle) keys(email, ip string) []string {
+	keys := []string{emailAttemptKey(email)}
+	if ip != "" {
+		keys = append(keys, ipAttemptKey(ip))
+	}
+	return keys
+}
+
+// Check returns a ResourceExhausted error carrying a "retry-after" header,
+// in seconds, while email or ip is blocked.
+func (t *LoginThrottle) Check(ctx context.Context, email, ip string) error {
+	now := time.Now()
+	var wait time.Duration
+	for _, key := range t.keys(email, ip) {
+		a, err := t.Store.Get(ctx, key)
+		if err != nil {
+			return status.Errorf(codes.Internal, "could not check login attempts: %v", err)
+		}
+		if d := a.LockedUntil.Sub(now); d > wait {
+			wait = d
+		}
+	}
+	if wait <= 0 {
+		return nil
+	}
+	seconds := int((wait + time.Second - 1) / time.Second)
+	// not running inside a gRPC call, e.g. in tests, is fine
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(seconds)))
+	return status.Errorf(codes.ResourceExhausted, "too many failed login attempts, retry in %d seconds", seconds)
+}
+
+// Fail records a failed login for email and ip and blocks them as needed.
+// Only the account backs off; the IP is locked once it reaches
+// MaxFailuresPerIP, since many riders can share it.
+func (t *LoginThrottle) Fail(ctx context.Context, email, ip string) error {
+	now := time.Now()
+	key := emailAttemptKey(email)
+	failures, err := t.Store.RecordFailure(ctx, key, now, t.Window)
+	if err != nil {
+		return err
+	}
+	if d := t.delay(failures); d > 0 {
+		if err := t.Store.Lock(ctx, key, now.Add(d)); err != nil {
+			return err
+		}
+	}
+	if ip == "" {
+		return nil
+	}
+	key = ipAttemptKey(ip)
+	failures, err = t.Store.RecordFailure(ctx, key, now, t.Window)
+	if err != nil {
+		return err
+	}
+	if failures >= t.MaxFailuresPerIP {
+		return t.Store.Lock(ctx, key, now.Add(t.LockoutDuration))
+	}
+	return nil
+}
+
+// Succeed clears the failures of email. The IP counter is kept, otherwise
+// an attacker could reset it by logging into an account of their own.
+func (t *LoginThrottle) Succeed(ctx context.Context, email string) error {
+	return t.Store.Reset(ctx, emailAttemptKey(email))
+}
+
+// Unlock clears the failures of email and, if given, ip.
+func (t *LoginThrottle) Unlock(ctx context.Context, email, ip string) error {
+	for _, key := range t.keys(email, ip) {
+		if err := t.Store.Reset(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Purge drops attempts that no longer affect logins.
+func (t *LoginThrottle) Purge(ctx context.Context) error {
+	return t.Store.Purge(ctx, time.Now().Add(-t.Window))
+}
+
+func (t *LoginThrottle) delay(failures int) time.Duration {
+	if failures >= t.MaxFailures {
+		return t.LockoutDuration
+	}
+	if failures < 2 {
+		return 0
+	}
+	if failures-2 >= 32 {
+		return t.LockoutDuration
+	}
+	d := t.BaseDelay << (failures - 2)
+	if d > t.LockoutDuration {
+		return t.LockoutDuration
+	}
+	return d
+}
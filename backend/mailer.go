@@ -0,0 +1,137 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Mail is a plain text email.
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers transactional mail such as password reset links.
+type Mailer interface {
+	Send(ctx context.Context, mail *Mail) error
+}
+
+// SMTPMailer sends mail through an SMTP relay. Username and Password are
+// optional; when set, PLAIN auth is used, which net/smtp only allows over
+// TLS or to localhost.
+type SMTPMailer struct {
+	Addr     string
+	From     string
+	Username string
+	Password string
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, mail *Mail) error {
+	var a smtp.Auth
+	if m.Username != "" {
+		host, _, err := net.SplitHostPort(m.Addr)
+		if err != nil {
+			return err
+		}
+		a = smtp.PlainAuth("", m.Username, m.Password, host)
+	}
+	// the envelope sender must be a bare address
+	sender := m.From
+	if addr, err := netmail.ParseAddress(m.From); err == nil {
+		sender = addr.Address
+	}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- smtp.SendMail(m.Addr, a, sender, []string{mail.To}, formatMail(m.From, mail))
+	}()
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// FileMailer writes mail to a file, or to stdout when Path is empty or "-",
+// instead of delivering it. Meant for local development and tests.
+type FileMailer struct {
+	Path string
+	From string
+
+	mu sync.Mutex
+}
+
+func (m *FileMailer) Send(ctx context.Context, mail *Mail) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var w io.Writer = os.Stdout
+	if m.Path != "" && m.Path != "-" {
+		f, err := os.OpenFile(m.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	_, err := w.Write(append(formatMail(m.From, mail), "\r\n"...))
+	return err
+}
+
+// ErrNoMailer is returned by NewMailerFromEnv when mail would silently go
+// to stdout.
+var ErrNoMailer = errors.New("no mailer configured, set SMTP_ADDR, or MAIL_FILE for development (MAIL_FILE=- writes to stdout)")
+
+// mailSendTimeout bounds mail that is sent after the request has returned.
+const mailSendTimeout = 30 * time.Second
+
+// NewMailerFromEnv returns an SMTPMailer when SMTP_ADDR (host:port) is set,
+// authenticating with SMTP_USERNAME and SMTP_PASSWORD if given. Otherwise
+// mail is written to MAIL_FILE, or stdout when it is "-". Without either it
+// fails with ErrNoMailer, so a production server does not start up unable
+// to deliver reset links. MAIL_FROM sets the sender.
+func NewMailerFromEnv() (Mailer, error) {
+	from := stringFromEnv("MAIL_FROM", "BikeRental <no-reply@bikerental.local>")
+	if addr := os.Getenv("SMTP_ADDR"); addr != "" {
+		return &SMTPMailer{
+			Addr:     addr,
+			From:     from,
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+		}, nil
+	}
+	if path := os.Getenv("MAIL_FILE"); path != "" {
+		return &FileMailer{Path: path, From: from}, nil
+	}
+	return nil, ErrNoMailer
+}
+
+func formatMail(from string, mail *Mail) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", headerValue(from))
+	fmt.Fprintf(&b, "To: %s\r\n", headerValue(mail.To))
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerValue(mail.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(mail.Body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+// headerValue drops line breaks so user supplied values cannot inject
+// additional headers.
+func headerValue(v string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(v)
+}
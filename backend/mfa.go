@@ -0,0 +1,264 @@
+package backend
+
+import (
+	"backend/auth"
+	"context"
+	"crypto/rand"
+	"db"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MFAChallengeTTL is how long the mfa_token returned by Login can be
+// passed to VerifyMFA.
+var MFAChallengeTTL = 5 * time.Minute
+
+// MFAIssuer is the account issuer shown in authenticator apps. Override
+// with MFA_ISSUER.
+var MFAIssuer = stringFromEnv("MFA_ISSUER", "BikeRental")
+
+const recoveryCodeCount = 10
+
+// mfaAudience keeps challenge tokens from being accepted as access tokens.
+func mfaAudience() string {
+	return Audience + ":mfa"
+}
+
+// GenerateMFAChallenge issues the short-lived token that proves the
+// password step of a two-step login succeeded.
+func GenerateMFAChallenge(email string, role string) (string, error) {
+	claims := NewClaims(email, role)
+	claims.AMR = []string{AMRPassword}
+	claims.Audience = jwt.ClaimStrings{mfaAudience()}
+	claims.ExpiresAt = jwt.NewNumericDate(claims.IssuedAt.Add(MFAChallengeTTL))
+	return Keys().Sign(claims)
+}
+
+func VerifyMFAChallenge(tokenStr string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, Keys().Keyfunc,
+		jwt.WithIssuer(Issuer),
+		jwt.WithAudience(mfaAudience()),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(30*time.Second),
+	)
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("invalid mfa token: %v", err)
+	}
+	return claims, nil
+}
+
+func (s *AuthenticatorServer) mfaChallenge(user *db.UserModel) (*LoginReply, error) {
+	token, err := GenerateMFAChallenge(user.Email, user.Role)
+	if err != nil {
+		log.Printf("Error generating mfa token: %v", err)
+		return nil, fmt.Errorf("could not generate token: %v", err)
+	}
+	return &LoginReply{
+		MfaRequired: true,
+		MfaToken:    token,
+	}, nil
+}
+
+func (s *AuthenticatorServer) currentUser(ctx context.Context) (*db.UserModel, error) {
+	email, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.PrismaClient.User.FindUnique(
+		db.User.Email.Equals(email),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return user, err
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/auth/mfa/enroll \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{}'
+*/
+func (s *AuthenticatorServer) EnrollMFA(ctx context.Context, in *EnrollMFARequest) (*EnrollMFAReply, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if user.MfaEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is already enabled")
+	}
+
+	// enrolling again replaces a secret that was never confirmed
+	secret := NewTOTPSecret()
+	_, err = s.PrismaClient.User.FindUnique(
+		db.User.ID.Equals(user.ID),
+	).Update(
+		db.User.MfaSecret.Set(secret),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Could not store mfa secret for user %d: %v", user.ID, err)
+		return nil, status.Error(codes.Internal, "could not enroll")
+	}
+	return &EnrollMFAReply{
+		Secret:     secret,
+		OtpauthUri: NewTOTP().URI(secret, MFAIssuer, user.Email),
+	}, nil
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/auth/mfa/confirm \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{
+	        "code": "123456"
+	      }'
+*/
+func (s *AuthenticatorServer) ConfirmMFA(ctx context.Context, in *ConfirmMFARequest) (*ConfirmMFAReply, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secret, ok := user.MfaSecret()
+	if !ok || user.MfaEnabled {
+		return nil, status.Error(codes.FailedPrecondition, "call EnrollMFA first")
+	}
+	step, ok := NewTOTP().Validate(secret, in.Code, time.Now(), 0)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid code")
+	}
+
+	recoveryCodes, hashes := newRecoveryCodes(recoveryCodeCount)
+	txs := []db.PrismaTransaction{
+		s.PrismaClient.RecoveryCode.FindMany(
+			db.RecoveryCode.UserID.Equals(user.ID),
+		).Delete().Tx(),
+		s.PrismaClient.User.FindUnique(
+			db.User.ID.Equals(user.ID),
+		).Update(
+			db.User.MfaEnabled.Set(true),
+			db.User.MfaLastStep.Set(int(step)),
+		).Tx(),
+	}
+	for _, hash := range hashes {
+		txs = append(txs, s.PrismaClient.RecoveryCode.CreateOne(
+			db.RecoveryCode.CodeHash.Set(hash),
+			db.RecoveryCode.User.Link(db.User.ID.Equals(user.ID)),
+		).Tx())
+	}
+	if err := s.PrismaClient.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		log.Printf("Could not enable mfa for user %d: %v", user.ID, err)
+		return nil, status.Error(codes.Internal, "could not enable two-factor authentication")
+	}
+	return &ConfirmMFAReply{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/auth/mfa/verify \
+	  -H 'Content-Type: application/json' \
+	  -d '{
+	        "mfa_token": "$MFA_TOKEN",
+	        "code": "123456"
+	      }'
+*/
+func (s *AuthenticatorServer) VerifyMFA(ctx context.Context, in *VerifyMFARequest) (*LoginReply, error) {
+	claims, err := VerifyMFAChallenge(in.MfaToken)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ip := auth.ClientIP(ctx)
+	if err := s.throttle().Check(ctx, claims.Email, ip); err != nil {
+		return nil, err
+	}
+	user, err := s.PrismaClient.User.FindUnique(
+		db.User.Email.Equals(claims.Email),
+	).Exec(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid mfa token")
+	}
+	secret, hasSecret := user.MfaSecret()
+	if !user.MfaEnabled || !hasSecret {
+		return nil, status.Error(codes.FailedPrecondition, "two-factor authentication is not enabled")
+	}
+
+	ok, err := s.consumeSecondFactor(ctx, user, secret, in.Code)
+	if err != nil {
+		log.Printf("Could not verify second factor of user %d: %v", user.ID, err)
+		return nil, status.Error(codes.Internal, "could not verify code")
+	}
+	if !ok {
+		s.loginFailed(ctx, claims.Email, ip)
+		return nil, status.Error(codes.Unauthenticated, "invalid code")
+	}
+	if err := s.throttle().Succeed(ctx, claims.Email); err != nil {
+		log.Printf("Could not reset failed logins for %s: %v", claims.Email, err)
+	}
+	return s.issueTokens(ctx, user, true)
+}
+
+// consumeSecondFactor accepts a TOTP code newer than the last one used, or
+// an unused recovery code. Either is marked as used with a conditional
+// update, so concurrent requests cannot use the same code twice.
+func (s *AuthenticatorServer) consumeSecondFactor(ctx context.Context, user *db.UserModel, secret string, code string) (bool, error) {
+	if step, ok := NewTOTP().Validate(secret, code, time.Now(), int64(user.MfaLastStep)); ok {
+		result, err := s.PrismaClient.User.FindMany(
+			db.User.ID.Equals(user.ID),
+			db.User.MfaLastStep.Lt(int(step)),
+		).Update(
+			db.User.MfaLastStep.Set(int(step)),
+		).Exec(ctx)
+		if err != nil {
+			return false, err
+		}
+		return result.Count == 1, nil
+	}
+
+	result, err := s.PrismaClient.RecoveryCode.FindMany(
+		db.RecoveryCode.CodeHash.Equals(hashToken(normalizeRecoveryCode(code))),
+		db.RecoveryCode.UserID.Equals(user.ID),
+		db.RecoveryCode.UsedAt.IsNull(),
+	).Update(
+		db.RecoveryCode.UsedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+	if result.Count == 1 {
+		log.Printf("User %d logged in with a recovery code", user.ID)
+	}
+	return result.Count == 1, nil
+}
+
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijkmnpqrstuvwxyz23456789").WithPadding(base32.NoPadding)
+
+// newRecoveryCodes returns n codes formatted as xxxxx-xxxxx and their
+// hashes for storage.
+func newRecoveryCodes(n int) ([]string, []string) {
+	codes := make([]string, n)
+	hashes := make([]string, n)
+	for i := range codes {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			panic(err)
+		}
+		code := recoveryCodeEncoding.EncodeToString(b)[:10]
+		codes[i] = code[:5] + "-" + code[5:]
+		hashes[i] = hashToken(code)
+	}
+	return codes, hashes
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+}
@@ -0,0 +1,161 @@
+package backend
+
+import (
+	"context"
+	"db"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultNearbyRadius = 500
+	maxNearbyRadius     = 10000
+	defaultNearbyLimit  = 20
+	maxNearbyLimit      = 100
+)
+
+// geohashEnd sorts after every geohash starting with a prefix, '{' being
+// the character after 'z'. Comparing with >= and < instead of LIKE lets
+// both SQLite and Postgres use the index on Station.geohash.
+const geohashEnd = "{"
+
+func inCells(cells []string) db.StationWhereParam {
+	var ranges []db.StationWhereParam
+	for _, cell := range cells {
+		ranges = append(ranges, db.Station.And(
+			db.Station.Geohash.Gte(cell),
+			db.Station.Geohash.Lt(cell+geohashEnd),
+		))
+	}
+	return db.Station.Or(ranges...)
+}
+
+/*
+	curl -X GET 'http://localhost:8080/v1/nearby?latitude=52.5251&longitude=13.3694&radius_meters=800&bike_type=electric&min_battery=30' \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *StationServer) SearchNearby(ctx context.Context, req *SearchNearbyRequest) (*SearchNearbyReply, error) {
+	if err := validCoordinates(req.Latitude, req.Longitude); err != nil {
+		return nil, err
+	}
+	radius := req.RadiusMeters
+	if radius <= 0 {
+		radius = defaultNearbyRadius
+	}
+	if radius > maxNearbyRadius {
+		return nil, status.Errorf(codes.InvalidArgument, "radius_meters must be at most %d", maxNearbyRadius)
+	}
+	if req.MinBattery < 0 || req.MinBattery > 100 {
+		return nil, status.Error(codes.InvalidArgument, "min_battery must be between 0 and 100")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultNearbyLimit
+	}
+	if limit > maxNearbyLimit {
+		limit = maxNearbyLimit
+	}
+
+	var filters []db.StationWhereParam
+	if cells := NearbyCells(req.Latitude, req.Longitude, radius); cells != nil {
+		filters = append(filters, inCells(cells))
+	}
+	candidates, err := server.PrismaClient.Station.FindMany(filters...).With(
+		stationDetails()...,
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the cells cover more than the circle, keep what is really in range
+	var nearby []*NearbyStation
+	for i := range candidates {
+		station := &candidates[i]
+		d := DistanceMeters(req.Latitude, req.Longitude, station.Latitude, station.Longitude)
+		if d > radius {
+			continue
+		}
+		nearby = append(nearby, &NearbyStation{Station: toStation(station, false), DistanceMeters: d})
+	}
+	sort.SliceStable(nearby, func(i, j int) bool {
+		return nearby[i].DistanceMeters < nearby[j].DistanceMeters
+	})
+	reply := &SearchNearbyReply{}
+	if len(nearby) > limit {
+		reply.Stations = nearby[:limit]
+	} else {
+		reply.Stations = nearby
+	}
+
+	// bikes are looked up limit stations at a time, nearest first, so a
+	// dense area does not load every bike in the radius
+	for first := 0; first < len(nearby) && len(reply.Bikes) < limit; first += limit {
+		batch := nearby[first:min(first+limit, len(nearby))]
+		distances := map[int32]float64{}
+		var stationIDs []int
+		for _, station := range batch {
+			distances[station.Station.Id] = station.DistanceMeters
+			stationIDs = append(stationIDs, int(station.Station.Id))
+		}
+		bikeFilters := []db.BikeWhereParam{
+			db.Bike.StationID.In(stationIDs),
+			db.Bike.Status.Equals(BikeStatus_AVAILABLE.String()),
+		}
+		if req.BikeType != "" {
+			bikeFilters = append(bikeFilters, db.Bike.Type.Equals(req.BikeType))
+		}
+		if req.MinBattery > 0 {
+			bikeFilters = append(bikeFilters, db.Bike.BatteryLevel.Gte(int(req.MinBattery)))
+		}
+		bikes, err := server.PrismaClient.Bike.FindMany(bikeFilters...).Exec(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for i := range bikes {
+			bike := toBike(&bikes[i])
+			reply.Bikes = append(reply.Bikes, &NearbyBike{
+				BikeId:         bike.Id,
+				Model:          bike.Model,
+				Type:           bike.Type,
+				BatteryLevel:   bike.BatteryLevel,
+				StationId:      bike.StationId,
+				DockId:         bike.DockId,
+				DistanceMeters: distances[bike.StationId],
+			})
+		}
+	}
+	sort.SliceStable(reply.Bikes, func(i, j int) bool {
+		if reply.Bikes[i].DistanceMeters != reply.Bikes[j].DistanceMeters {
+			return reply.Bikes[i].DistanceMeters < reply.Bikes[j].DistanceMeters
+		}
+		return reply.Bikes[i].BikeId < reply.Bikes[j].BikeId
+	})
+	if len(reply.Bikes) > limit {
+		reply.Bikes = reply.Bikes[:limit]
+	}
+	return reply, nil
+}
+
+// IndexStations computes the geohash of stations created before it was
+// stored and returns how many it updated. It is safe to run on every start.
+func IndexStations(ctx context.Context, client *db.PrismaClient) (int, error) {
+	stations, err := client.Station.FindMany(
+		db.Station.Geohash.Equals(""),
+	).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for i, station := range stations {
+		_, err := client.Station.FindUnique(
+			db.Station.ID.Equals(station.ID),
+		).Update(
+			db.Station.Geohash.Set(Geohash(station.Latitude, station.Longitude, stationGeohashPrecision)),
+		).Exec(ctx)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(stations), nil
+}
@@ -0,0 +1,371 @@
+package backend
+
+import (
+	"context"
+	"crypto/rand"
+	"db"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	oidcCookieName = "oidc_login"
+	oidcCookiePath = "/v1/auth/oidc"
+	// oidcLoginTTL is how long the user has to finish signing in at the
+	// identity provider.
+	oidcLoginTTL = 10 * time.Minute
+)
+
+var errOIDCUnknownUser = errors.New("no account is linked to this identity")
+
+// OIDCLogin signs users in through an external OpenID Connect identity
+// provider with the authorization code flow. Start redirects the browser to
+// the provider, which sends it back to Callback. The provider's subject is
+// mapped to a User through ExternalIdentity and the reply is the same
+// LoginReply the Login RPC returns.
+type OIDCLogin struct {
+	PrismaClient *db.PrismaClient
+	// Tokens defaults to a TokenStore backed by PrismaClient when nil.
+	Tokens   *TokenStore
+	Issuer   string
+	OAuth2   oauth2.Config
+	Verifier *oidc.IDTokenVerifier
+	// AutoProvision creates a User for identities that match no account.
+	// Without it, unknown identities are refused.
+	AutoProvision bool
+	// DefaultRole is given to provisioned users.
+	DefaultRole string
+	// TrustMFA treats every login as two-factor, for providers that enforce
+	// it but do not report it in the amr claim.
+	TrustMFA bool
+
+	tokensOnce sync.Once
+}
+
+// NewOIDCLogin discovers the provider at issuer. redirectURL must point at
+// the route Callback is mounted on.
+func NewOIDCLogin(ctx context.Context, client *db.PrismaClient, issuer, clientID, clientSecret, redirectURL string) (*OIDCLogin, error) {
+	provider, err := oidc.NewProvider(ctx, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("could not discover OIDC provider: %w", err)
+	}
+	return &OIDCLogin{
+		PrismaClient: client,
+		Issuer:       issuer,
+		OAuth2: oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+		},
+		Verifier:    provider.Verifier(&oidc.Config{ClientID: clientID}),
+		DefaultRole: RoleRider,
+	}, nil
+}
+
+// NewOIDCLoginFromEnv returns nil when OIDC_ISSUER is not set. Otherwise
+// OIDC_CLIENT_ID, OIDC_CLIENT_SECRET and OIDC_REDIRECT_URL are required,
+// and OIDC_AUTO_PROVISION, OIDC_DEFAULT_ROLE and OIDC_TRUST_MFA are
+// optional.
+func NewOIDCLoginFromEnv(ctx context.Context, client *db.PrismaClient) (*OIDCLogin, error) {
+	issuer := os.Getenv("OIDC_ISSUER")
+	if issuer == "" {
+		return nil, nil
+	}
+	clientID := os.Getenv("OIDC_CLIENT_ID")
+	redirectURL := os.Getenv("OIDC_REDIRECT_URL")
+	if clientID == "" || redirectURL == "" {
+		return nil, errors.New("OIDC_CLIENT_ID and OIDC_REDIRECT_URL are required with OIDC_ISSUER")
+	}
+	l, err := NewOIDCLogin(ctx, client, issuer, clientID, os.Getenv("OIDC_CLIENT_SECRET"), redirectURL)
+	if err != nil {
+		return nil, err
+	}
+	l.AutoProvision = boolFromEnv("OIDC_AUTO_PROVISION", false)
+	l.DefaultRole = stringFromEnv("OIDC_DEFAULT_ROLE", l.DefaultRole)
+	if !ValidRole(l.DefaultRole) {
+		return nil, fmt.Errorf("invalid OIDC_DEFAULT_ROLE %q", l.DefaultRole)
+	}
+	l.TrustMFA = boolFromEnv("OIDC_TRUST_MFA", false)
+	return l, nil
+}
+
+func (l *OIDCLogin) tokens() *TokenStore {
+	l.tokensOnce.Do(func() {
+		if l.Tokens == nil {
+			l.Tokens = &TokenStore{PrismaClient: l.PrismaClient}
+		}
+	})
+	return l.Tokens
+}
+
+// oidcState is kept in a cookie between Start and Callback.
+type oidcState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+type oidcClaims struct {
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	GivenName     string   `json:"given_name"`
+	FamilyName    string   `json:"family_name"`
+	AMR           []string `json:"amr"`
+}
+
+// mfa reports whether the provider says a second factor was used.
+func (c *oidcClaims) mfa() bool {
+	for _, m := range c.AMR {
+		switch m {
+		case "mfa", "otp", "hwk", "sms":
+			return true
+		}
+	}
+	return false
+}
+
+/*
+	curl -i -c cookies.txt \
+	  http://localhost:8080/v1/auth/oidc/start
+*/
+func (l *OIDCLogin) Start(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	st := oidcState{
+		State:    randomString(),
+		Nonce:    randomString(),
+		Verifier: oauth2.GenerateVerifier(),
+	}
+	data, err := json.Marshal(st)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(data),
+		Path:     oidcCookiePath,
+		MaxAge:   int(oidcLoginTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(l.OAuth2.RedirectURL, "https://"),
+		// Lax still sends the cookie on the provider's top level redirect
+		SameSite: http.SameSiteLaxMode,
+	})
+	url := l.OAuth2.AuthCodeURL(st.State,
+		oidc.Nonce(st.Nonce),
+		oauth2.S256ChallengeOption(st.Verifier),
+	)
+	http.Redirect(w, r, url, http.StatusFound)
+}
+
+// Callback completes the login the provider redirected back from.
+func (l *OIDCLogin) Callback(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+	st, err := readOIDCState(r)
+	// the cookie is single use
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookieName,
+		Path:     oidcCookiePath,
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+	if err != nil {
+		http.Error(w, "login expired, start again", http.StatusBadRequest)
+		return
+	}
+	query := r.URL.Query()
+	if e := query.Get("error"); e != "" {
+		http.Error(w, fmt.Sprintf("identity provider refused the login: %s", e), http.StatusUnauthorized)
+		return
+	}
+	if query.Get("state") != st.State {
+		http.Error(w, "state does not match", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	token, err := l.OAuth2.Exchange(ctx, query.Get("code"), oauth2.VerifierOption(st.Verifier))
+	if err != nil {
+		log.Printf("Could not exchange OIDC code: %v", err)
+		http.Error(w, "could not complete login", http.StatusUnauthorized)
+		return
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		http.Error(w, "identity provider returned no id_token", http.StatusUnauthorized)
+		return
+	}
+	idToken, err := l.Verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		log.Printf("Invalid OIDC id_token: %v", err)
+		http.Error(w, "invalid id_token", http.StatusUnauthorized)
+		return
+	}
+	if idToken.Nonce != st.Nonce {
+		http.Error(w, "nonce does not match", http.StatusUnauthorized)
+		return
+	}
+	var claims oidcClaims
+	if err := idToken.Claims(&claims); err != nil {
+		http.Error(w, "invalid id_token claims", http.StatusUnauthorized)
+		return
+	}
+
+	user, err := l.findOrCreateUser(ctx, idToken.Subject, &claims)
+	if errors.Is(err, errOIDCUnknownUser) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		log.Printf("Could not map OIDC subject %s: %v", idToken.Subject, err)
+		http.Error(w, "could not complete login", http.StatusInternalServerError)
+		return
+	}
+
+	reply, err := l.login(ctx, user, l.TrustMFA || claims.mfa())
+	if status.Code(err) == codes.PermissionDenied {
+		http.Error(w, status.Convert(err).Message(), http.StatusForbidden)
+		return
+	}
+	if err != nil {
+		log.Printf("Could not log in OIDC user %d: %v", user.ID, err)
+		http.Error(w, "could not complete login", http.StatusInternalServerError)
+		return
+	}
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(reply)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(data)
+}
+
+// login issues tokens like the Login RPC. Users with local two-factor
+// authentication still have to pass VerifyMFA unless the provider already
+// checked a second factor.
+func (l *OIDCLogin) login(ctx context.Context, user *db.UserModel, mfa bool) (*LoginReply, error) {
+	s := &AuthenticatorServer{
+		PrismaClient: l.PrismaClient,
+		Tokens:       l.tokens(),
+	}
+	// issueTokens checks this too, but a disabled user is not to be
+	// asked for a second factor first
+	if err := requireEnabled(user); err != nil {
+		return nil, err
+	}
+	if user.MfaEnabled && !mfa {
+		return s.mfaChallenge(user)
+	}
+	return s.issueTokens(ctx, user, mfa)
+}
+
+// findOrCreateUser looks the identity up by issuer and subject. An unknown
+// identity is linked to the account with the same email, as long as the
+// provider verified that email, or provisioned if AutoProvision is set.
+func (l *OIDCLogin) findOrCreateUser(ctx context.Context, subject string, claims *oidcClaims) (*db.UserModel, error) {
+	identity, err := l.PrismaClient.ExternalIdentity.FindUnique(
+		db.ExternalIdentity.IssuerSubject(
+			db.ExternalIdentity.Issuer.Equals(l.Issuer),
+			db.ExternalIdentity.Subject.Equals(subject),
+		),
+	).With(
+		db.ExternalIdentity.User.Fetch(),
+	).Exec(ctx)
+	if err == nil {
+		return identity.User(), nil
+	}
+	if !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, errOIDCUnknownUser
+	}
+
+	user, err := l.PrismaClient.User.FindUnique(
+		db.User.Email.Equals(claims.Email),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		if !l.AutoProvision {
+			return nil, errOIDCUnknownUser
+		}
+		user, err = l.provisionUser(ctx, claims)
+	}
+	if err != nil {
+		return nil, err
+	}
+	_, err = l.PrismaClient.ExternalIdentity.CreateOne(
+		db.ExternalIdentity.Issuer.Set(l.Issuer),
+		db.ExternalIdentity.Subject.Set(subject),
+		db.ExternalIdentity.User.Link(db.User.ID.Equals(user.ID)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Linked OIDC subject %s to user %d", subject, user.ID)
+	return user, nil
+}
+
+func (l *OIDCLogin) provisionUser(ctx context.Context, claims *oidcClaims) (*db.UserModel, error) {
+	// the account can only be used through the provider until a password
+	// is set with the reset flow
+	hash, err := NewPasswordHasherFromEnv().Hash(randomString())
+	if err != nil {
+		return nil, err
+	}
+	params := []db.UserSetParam{
+		db.User.Role.Set(l.DefaultRole),
+		db.User.EmailVerified.Set(true),
+	}
+	if claims.GivenName != "" {
+		params = append(params, db.User.Name.Set(claims.GivenName))
+	}
+	return l.PrismaClient.User.CreateOne(
+		db.User.Email.Set(claims.Email),
+		db.User.Password.Set(hash),
+		db.User.Surname.Set(claims.FamilyName),
+		db.User.Age.Set(0),
+		params...,
+	).Exec(ctx)
+}
+
+func readOIDCState(r *http.Request) (*oidcState, error) {
+	cookie, err := r.Cookie(oidcCookieName)
+	if err != nil {
+		return nil, err
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil, err
+	}
+	st := &oidcState{}
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, err
+	}
+	if st.State == "" || st.Nonce == "" || st.Verifier == "" {
+		return nil, errors.New("incomplete login state")
+	}
+	return st, nil
+}
+
+func randomString() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,18 @@
+package backend
+
+// paginate turns a 1-based page and a page size from a request into the
+// take and skip of a query. A missing page size falls back to def and
+// larger ones are capped at max.
+func paginate(page, pageSize int32, def, max int) (take int, skip int) {
+	take = int(pageSize)
+	if take <= 0 {
+		take = def
+	}
+	if take > max {
+		take = max
+	}
+	if page < 1 {
+		page = 1
+	}
+	return take, (int(page) - 1) * take
+}
@@ -0,0 +1,187 @@
+package backend
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordHasher hashes passwords for storage in User.password and checks
+// login attempts against stored hashes.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify reports whether password matches the stored value. Stored
+	// values that are not hashes at all (legacy plaintext rows) are compared
+	// directly so they can be upgraded on the next successful login.
+	Verify(stored, password string) (bool, error)
+	// NeedsRehash reports whether stored should be replaced by a fresh hash,
+	// either because it is plaintext or because it was produced with
+	// different parameters than the hasher's current ones.
+	NeedsRehash(stored string) bool
+}
+
+type BcryptHasher struct {
+	Cost int
+}
+
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptHasher{Cost: cost}
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), h.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (h *BcryptHasher) Verify(stored, password string) (bool, error) {
+	return verifyPassword(stored, password)
+}
+
+func (h *BcryptHasher) NeedsRehash(stored string) bool {
+	if !isBcryptHash(stored) {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(stored))
+	return err != nil || cost != h.Cost
+}
+
+type Argon2idHasher struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	KeyLen  uint32
+	SaltLen uint32
+}
+
+func NewArgon2idHasher() *Argon2idHasher {
+	return &Argon2idHasher{
+		Time:    1,
+		Memory:  64 * 1024,
+		Threads: 4,
+		KeyLen:  32,
+		SaltLen: 16,
+	}
+}
+
+// Hash encodes the result in the PHC string format, e.g.
+// $argon2id$v=19$m=65536,t=1,p=4$<salt>$<hash>
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key := argon2.IDKey([]byte(password), salt, h.Time, h.Memory, h.Threads, h.KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.Memory, h.Time, h.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2idHasher) Verify(stored, password string) (bool, error) {
+	return verifyPassword(stored, password)
+}
+
+func (h *Argon2idHasher) NeedsRehash(stored string) bool {
+	params, _, key, err := decodeArgon2id(stored)
+	if err != nil {
+		return true
+	}
+	return params.Time != h.Time || params.Memory != h.Memory ||
+		params.Threads != h.Threads || uint32(len(key)) != h.KeyLen
+}
+
+// NewPasswordHasherFromEnv picks the hashing algorithm from PASSWORD_HASH
+// ("bcrypt" or "argon2id", default bcrypt). BCRYPT_COST sets the bcrypt
+// cost; ARGON2_TIME, ARGON2_MEMORY (KiB) and ARGON2_THREADS tune argon2id.
+func NewPasswordHasherFromEnv() PasswordHasher {
+	switch strings.ToLower(os.Getenv("PASSWORD_HASH")) {
+	case "argon2id":
+		h := NewArgon2idHasher()
+		if v, err := strconv.ParseUint(os.Getenv("ARGON2_TIME"), 10, 32); err == nil && v > 0 {
+			h.Time = uint32(v)
+		}
+		if v, err := strconv.ParseUint(os.Getenv("ARGON2_MEMORY"), 10, 32); err == nil && v > 0 {
+			h.Memory = uint32(v)
+		}
+		if v, err := strconv.ParseUint(os.Getenv("ARGON2_THREADS"), 10, 8); err == nil && v > 0 {
+			h.Threads = uint8(v)
+		}
+		return h
+	default:
+		cost, _ := strconv.Atoi(os.Getenv("BCRYPT_COST"))
+		return NewBcryptHasher(cost)
+	}
+}
+
+// verifyPassword checks password against any supported stored format, so
+// switching PASSWORD_HASH does not lock out users hashed with the other one.
+func verifyPassword(stored, password string) (bool, error) {
+	switch {
+	case isBcryptHash(stored):
+		err := bcrypt.CompareHashAndPassword([]byte(stored), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	case strings.HasPrefix(stored, "$argon2id$"):
+		params, salt, key, err := decodeArgon2id(stored)
+		if err != nil {
+			return false, err
+		}
+		other := argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, uint32(len(key)))
+		return subtle.ConstantTimeCompare(key, other) == 1, nil
+	default:
+		// legacy plaintext row
+		return subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1, nil
+	}
+}
+
+func isBcryptHash(stored string) bool {
+	return strings.HasPrefix(stored, "$2a$") ||
+		strings.HasPrefix(stored, "$2b$") ||
+		strings.HasPrefix(stored, "$2y$")
+}
+
+func decodeArgon2id(stored string) (*Argon2idHasher, []byte, []byte, error) {
+	parts := strings.Split(stored, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, nil, nil, fmt.Errorf("invalid argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, nil, nil, err
+	}
+	if version != argon2.Version {
+		return nil, nil, nil, fmt.Errorf("unsupported argon2 version %d", version)
+	}
+	params := &Argon2idHasher{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads); err != nil {
+		return nil, nil, nil, err
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	params.KeyLen = uint32(len(key))
+	params.SaltLen = uint32(len(salt))
+	return params, salt, key, nil
+}
@@ -0,0 +1,63 @@
+package backend
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var commonPasswords = loadCommonPasswords(commonPasswordsFile)
+
+func loadCommonPasswords(data string) map[string]struct{} {
+	passwords := make(map[string]struct{})
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+	return passwords
+}
+
+// PasswordPolicy is applied to new passwords in Register.
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+}
+
+func NewPasswordPolicy() *PasswordPolicy {
+	return &PasswordPolicy{
+		MinLength: 8,
+		// bcrypt ignores everything past 72 bytes
+		MaxLength: 72,
+	}
+}
+
+// NewPasswordPolicyFromEnv reads PASSWORD_MIN_LENGTH on top of the defaults.
+func NewPasswordPolicyFromEnv() *PasswordPolicy {
+	policy := NewPasswordPolicy()
+	if v, err := strconv.Atoi(os.Getenv("PASSWORD_MIN_LENGTH")); err == nil && v > 0 {
+		policy.MinLength = v
+	}
+	return policy
+}
+
+func (p *PasswordPolicy) Validate(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters long", p.MinLength)
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		return fmt.Errorf("password must be at most %d bytes long", p.MaxLength)
+	}
+	if _, ok := commonPasswords[strings.ToLower(password)]; ok {
+		return fmt.Errorf("password is too common")
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package backend
+
+import (
+	"context"
+	"db"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PasswordResetTTL is how long a reset token stays valid. Override with
+// PASSWORD_RESET_TTL.
+var PasswordResetTTL = durationFromEnv("PASSWORD_RESET_TTL", time.Hour)
+
+var ErrPasswordResetInvalid = errors.New("invalid or expired password reset token")
+
+// requestPasswordResetReply is returned whether or not the account exists,
+// so the endpoint cannot be used to probe for registered addresses.
+const requestPasswordResetReply = "If an account exists for this address, a reset link has been sent"
+
+/*
+	curl -X POST http://localhost:8080/v1/auth/password-reset \
+	  -H 'Content-Type: application/json' \
+	  -d '{
+	        "email": "newuser@example.com"
+	      }'
+*/
+func (s *AuthenticatorServer) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest) (*RequestPasswordResetReply, error) {
+	reply := &RequestPasswordResetReply{
+		Reply: requestPasswordResetReply,
+	}
+	user, err := s.PrismaClient.User.FindUnique(
+		db.User.Email.Equals(in.Email),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return reply, nil
+	}
+	if err != nil {
+		log.Printf("Could not look up user for password reset: %v", err)
+		return nil, status.Error(codes.Internal, "could not request password reset")
+	}
+
+	token, hash := newRefreshToken()
+	_, err = s.PrismaClient.PasswordResetToken.CreateOne(
+		db.PasswordResetToken.TokenHash.Set(hash),
+		db.PasswordResetToken.User.Link(db.User.ID.Equals(user.ID)),
+		db.PasswordResetToken.ExpiresAt.Set(time.Now().Add(PasswordResetTTL)),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Could not store password reset token: %v", err)
+		return nil, status.Error(codes.Internal, "could not request password reset")
+	}
+
+	// delivery can take seconds, which would tell registered addresses
+	// apart from unknown ones, so the reply does not wait for it
+	mailer := s.mailer()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+		defer cancel()
+		if err := mailer.Send(ctx, passwordResetMail(user.Email, token)); err != nil {
+			log.Printf("Could not send password reset mail to user %d: %v", user.ID, err)
+		}
+	}()
+	return reply, nil
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/auth/password-reset/confirm \
+	  -H 'Content-Type: application/json' \
+	  -d '{
+	        "token": "$RESET_TOKEN",
+	        "new_password": "tandem-velodrome-43"
+	      }'
+*/
+func (s *AuthenticatorServer) ConfirmPasswordReset(ctx context.Context, in *ConfirmPasswordResetRequest) (*ConfirmPasswordResetReply, error) {
+	if err := s.passwordPolicy().Validate(in.NewPassword); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	stored, err := s.PrismaClient.PasswordResetToken.FindUnique(
+		db.PasswordResetToken.TokenHash.Equals(hashToken(in.Token)),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.InvalidArgument, ErrPasswordResetInvalid.Error())
+	}
+	if err != nil {
+		log.Printf("Could not look up password reset token: %v", err)
+		return nil, status.Error(codes.Internal, "could not reset password")
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return nil, status.Error(codes.InvalidArgument, ErrPasswordResetInvalid.Error())
+	}
+
+	// consuming every outstanding token of the user at once makes the token
+	// single-use even under concurrent requests, and voids older links
+	result, err := s.PrismaClient.PasswordResetToken.FindMany(
+		db.PasswordResetToken.UserID.Equals(stored.UserID),
+		db.PasswordResetToken.UsedAt.IsNull(),
+	).Update(
+		db.PasswordResetToken.UsedAt.Set(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		log.Printf("Could not consume password reset token: %v", err)
+		return nil, status.Error(codes.Internal, "could not reset password")
+	}
+	if _, used := stored.UsedAt(); used || result.Count == 0 {
+		return nil, status.Error(codes.InvalidArgument, ErrPasswordResetInvalid.Error())
+	}
+
+	if err := s.rehashPassword(ctx, stored.UserID, in.NewPassword); err != nil {
+		log.Printf("Could not update password of user %d: %v", stored.UserID, err)
+		return nil, status.Error(codes.Internal, "could not reset password")
+	}
+	// whoever knew the old password must not stay logged in
+	if err := s.tokens().RevokeUserRefreshTokens(ctx, stored.UserID); err != nil {
+		log.Printf("Could not revoke refresh tokens of user %d: %v", stored.UserID, err)
+	}
+	return &ConfirmPasswordResetReply{
+		Reply: "Password has been reset",
+	}, nil
+}
+
+// passwordResetMail links to PASSWORD_RESET_URL when it is set, otherwise
+// the token is sent on its own.
+func passwordResetMail(to string, token string) *Mail {
+	body := fmt.Sprintf("Use this token to reset your BikeRental password:\n\n%s\n", token)
+	if url := os.Getenv("PASSWORD_RESET_URL"); url != "" {
+		body = fmt.Sprintf("Open this link to reset your BikeRental password:\n\n%s?token=%s\n", url, token)
+	}
+	body += fmt.Sprintf("\nIt expires in %s. If you did not ask for a reset, ignore this mail.\n", PasswordResetTTL)
+	return &Mail{
+		To:      to,
+		Subject: "Reset your BikeRental password",
+		Body:    body,
+	}
+}
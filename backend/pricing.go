@@ -0,0 +1,264 @@
+package backend
+
+import (
+	"context"
+	"db"
+	"errors"
+	"log"
+	"math"
+	"os"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DefaultBikeType is the type of bikes created without one.
+const DefaultBikeType = "standard"
+
+// DefaultTariff prices bike types no tariff has been published for.
+var DefaultTariff = &Tariff{
+	UnlockFeeCents:    100,
+	PerMinuteCents:    15,
+	NightMultiplier:   1,
+	WeekendMultiplier: 1,
+}
+
+// Night surcharges apply to minutes starting from nightStartHour until
+// nightEndHour in PricingLocation.
+const (
+	nightStartHour = 22
+	nightEndHour   = 6
+	minutesPerDay  = 24 * 60
+)
+
+// PricingLocation is the time zone night and weekend surcharges follow.
+// Override with PRICING_TIMEZONE, e.g. Europe/Berlin.
+var PricingLocation = locationFromEnv("PRICING_TIMEZONE", time.UTC)
+
+func locationFromEnv(name string, fallback *time.Location) *time.Location {
+	if v := os.Getenv(name); v != "" {
+		loc, err := time.LoadLocation(v)
+		if err == nil {
+			return loc
+		}
+		log.Printf("Ignoring %s: %v", name, err)
+	}
+	return fallback
+}
+
+func multiplier(m float64) float64 {
+	if m <= 0 {
+		return 1
+	}
+	return m
+}
+
+// PriceRental returns the cost in cents of a rental from start to end.
+// Every started minute is charged at the per-minute rate, times the night
+// and weekend multipliers in effect when the minute starts. Minute charges
+// are capped per 24 hours counted from start; the unlock fee is charged
+// once. The multipliers only change on the hour, so minutes are charged an
+// hour at a time and long rentals cost at most 24 steps per day.
+func PriceRental(tariff *Tariff, start, end time.Time) int {
+	minutes := 0
+	if d := end.Sub(start); d > 0 {
+		minutes = int((d + time.Minute - 1) / time.Minute)
+	}
+	total := int(tariff.UnlockFeeCents)
+	for day := 0; day*minutesPerDay < minutes; day++ {
+		last := min((day+1)*minutesPerDay, minutes)
+		var charge float64
+		for m := day * minutesPerDay; m < last; {
+			at := start.Add(time.Duration(m) * time.Minute).In(PricingLocation)
+			// every minute starting before the next hour has the same rate
+			n := min(int((nextHour(at).Sub(at)+time.Minute-1)/time.Minute), last-m)
+			charge += minuteRate(tariff, at) * float64(n)
+			m += n
+		}
+		cents := int(math.Round(charge))
+		if tariff.DailyCapCents > 0 && cents > int(tariff.DailyCapCents) {
+			cents = int(tariff.DailyCapCents)
+		}
+		total += cents
+	}
+	return total
+}
+
+// minuteRate returns the price in cents of a minute starting at at.
+func minuteRate(tariff *Tariff, at time.Time) float64 {
+	rate := float64(tariff.PerMinuteCents)
+	if h := at.Hour(); h >= nightStartHour || h < nightEndHour {
+		rate *= multiplier(tariff.NightMultiplier)
+	}
+	if wd := at.Weekday(); wd == time.Saturday || wd == time.Sunday {
+		rate *= multiplier(tariff.WeekendMultiplier)
+	}
+	return rate
+}
+
+// nextHour returns the start of the local hour after at.
+func nextHour(at time.Time) time.Time {
+	next := time.Date(at.Year(), at.Month(), at.Day(), at.Hour()+1, 0, 0, 0, at.Location())
+	if !next.After(at) {
+		// the clock went back an hour for daylight saving time
+		next = next.Add(time.Hour)
+	}
+	return next
+}
+
+// centsToInt32 keeps very large amounts from wrapping around in replies.
+func centsToInt32(cents int) int32 {
+	if cents > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int32(cents)
+}
+
+func toTariff(tariff *db.TariffModel) *Tariff {
+	return &Tariff{
+		Id:                int32(tariff.ID),
+		BikeType:          tariff.BikeType,
+		Version:           int32(tariff.Version),
+		UnlockFeeCents:    int32(tariff.UnlockFeeCents),
+		PerMinuteCents:    int32(tariff.PerMinuteCents),
+		DailyCapCents:     int32(tariff.DailyCapCents),
+		NightMultiplier:   tariff.NightMultiplier,
+		WeekendMultiplier: tariff.WeekendMultiplier,
+		ValidFrom:         timestamppb.New(tariff.ValidFrom),
+		CreatedAt:         timestamppb.New(tariff.CreatedAt),
+	}
+}
+
+// tariffFor returns the tariff for bikeType that took over last before at,
+// or DefaultTariff when there is none. DefaultTariff has no id.
+func tariffFor(ctx context.Context, client *db.PrismaClient, bikeType string, at time.Time) (*Tariff, error) {
+	tariff, err := client.Tariff.FindFirst(
+		db.Tariff.BikeType.Equals(bikeType),
+		db.Tariff.ValidFrom.Lte(at),
+	).OrderBy(
+		db.Tariff.ValidFrom.Order(db.SortOrderDesc),
+		db.Tariff.Version.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		fallback := proto.Clone(DefaultTariff).(*Tariff)
+		fallback.BikeType = bikeType
+		return fallback, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toTariff(tariff), nil
+}
+
+// rentalTariff returns the tariff rental is charged by, the one recorded
+// when it started. Rentals without one, started without a published tariff
+// or before tariffs were recorded, pay what was valid at their start; since
+// tariffs cannot take over in the past, that cannot change during the ride.
+func rentalTariff(ctx context.Context, client *db.PrismaClient, rental *db.RentalModel) (*Tariff, error) {
+	id, ok := rental.TariffID()
+	if !ok {
+		return tariffFor(ctx, client, rental.Bike().Type, rental.StartTime)
+	}
+	tariff, err := client.Tariff.FindUnique(
+		db.Tariff.ID.Equals(id),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toTariff(tariff), nil
+}
+
+type PricingServer struct {
+	UnimplementedPricingServiceServer
+	PrismaClient *db.PrismaClient
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/tariffs \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{
+	        "bike_type": "electric",
+	        "unlock_fee_cents": 100,
+	        "per_minute_cents": 25,
+	        "daily_cap_cents": 3000,
+	        "night_multiplier": 1.5
+	      }'
+*/
+func (server *PricingServer) CreateTariff(ctx context.Context, req *CreateTariffRequest) (*Tariff, error) {
+	if req.BikeType == "" {
+		return nil, status.Error(codes.InvalidArgument, "bike_type is required")
+	}
+	if req.UnlockFeeCents < 0 || req.PerMinuteCents < 0 || req.DailyCapCents < 0 {
+		return nil, status.Error(codes.InvalidArgument, "prices cannot be negative")
+	}
+	if req.NightMultiplier < 0 || req.WeekendMultiplier < 0 {
+		return nil, status.Error(codes.InvalidArgument, "multipliers cannot be negative")
+	}
+	// running rentals keep the tariff they started with, but quotes and
+	// the history would change if a tariff could take over in the past
+	validFrom := time.Now()
+	if req.ValidFrom != nil {
+		if req.ValidFrom.AsTime().Before(validFrom) {
+			return nil, status.Error(codes.InvalidArgument, "valid_from cannot be in the past")
+		}
+		validFrom = req.ValidFrom.AsTime()
+	}
+
+	version := 1
+	latest, err := server.PrismaClient.Tariff.FindFirst(
+		db.Tariff.BikeType.Equals(req.BikeType),
+	).OrderBy(
+		db.Tariff.Version.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err == nil {
+		version = latest.Version + 1
+	} else if !errors.Is(err, db.ErrNotFound) {
+		return nil, err
+	}
+
+	tariff, err := server.PrismaClient.Tariff.CreateOne(
+		db.Tariff.BikeType.Set(req.BikeType),
+		db.Tariff.Version.Set(version),
+		db.Tariff.UnlockFeeCents.Set(int(req.UnlockFeeCents)),
+		db.Tariff.PerMinuteCents.Set(int(req.PerMinuteCents)),
+		db.Tariff.DailyCapCents.Set(int(req.DailyCapCents)),
+		db.Tariff.NightMultiplier.Set(multiplier(req.NightMultiplier)),
+		db.Tariff.WeekendMultiplier.Set(multiplier(req.WeekendMultiplier)),
+		db.Tariff.ValidFrom.Set(validFrom),
+	).Exec(ctx)
+	if _, ok := db.IsErrUniqueConstraint(err); ok {
+		return nil, status.Errorf(codes.Aborted, "another %s tariff was published concurrently, try again", req.BikeType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Tariff %s version %d published", tariff.BikeType, tariff.Version)
+	return toTariff(tariff), nil
+}
+
+/*
+	curl -X GET 'http://localhost:8080/v1/tariffs?bike_type=electric' \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *PricingServer) ListTariffs(ctx context.Context, req *ListTariffsRequest) (*ListTariffsReply, error) {
+	var filters []db.TariffWhereParam
+	if req.BikeType != "" {
+		filters = append(filters, db.Tariff.BikeType.Equals(req.BikeType))
+	}
+	tariffs, err := server.PrismaClient.Tariff.FindMany(filters...).OrderBy(
+		db.Tariff.BikeType.Order(db.SortOrderAsc),
+		db.Tariff.Version.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply := &ListTariffsReply{}
+	for i := range tariffs {
+		reply.Tariffs = append(reply.Tariffs, toTariff(&tariffs[i]))
+	}
+	return reply, nil
+}
@@ -0,0 +1,468 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        v3.21.12
+// source: pricing.proto
+
+package backend
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Tariff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BikeType       string `protobuf:"bytes,2,opt,name=bike_type,json=bikeType,proto3" json:"bike_type,omitempty"`
+	Version        int32  `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	UnlockFeeCents int32  `protobuf:"varint,4,opt,name=unlock_fee_cents,json=unlockFeeCents,proto3" json:"unlock_fee_cents,omitempty"`
+	PerMinuteCents int32  `protobuf:"varint,5,opt,name=per_minute_cents,json=perMinuteCents,proto3" json:"per_minute_cents,omitempty"`
+	// most a rider pays in minute charges per 24 hours, no cap when 0
+	DailyCapCents int32 `protobuf:"varint,6,opt,name=daily_cap_cents,json=dailyCapCents,proto3" json:"daily_cap_cents,omitempty"`
+	// applied to minutes between 22:00 and 06:00
+	NightMultiplier float64 `protobuf:"fixed64,7,opt,name=night_multiplier,json=nightMultiplier,proto3" json:"night_multiplier,omitempty"`
+	// applied to minutes on Saturdays and Sundays
+	WeekendMultiplier float64                `protobuf:"fixed64,8,opt,name=weekend_multiplier,json=weekendMultiplier,proto3" json:"weekend_multiplier,omitempty"`
+	ValidFrom         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *Tariff) Reset() {
+	*x = Tariff{}
+	mi := &file_pricing_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tariff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tariff) ProtoMessage() {}
+
+func (x *Tariff) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tariff.ProtoReflect.Descriptor instead.
+func (*Tariff) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Tariff) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Tariff) GetBikeType() string {
+	if x != nil {
+		return x.BikeType
+	}
+	return ""
+}
+
+func (x *Tariff) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Tariff) GetUnlockFeeCents() int32 {
+	if x != nil {
+		return x.UnlockFeeCents
+	}
+	return 0
+}
+
+func (x *Tariff) GetPerMinuteCents() int32 {
+	if x != nil {
+		return x.PerMinuteCents
+	}
+	return 0
+}
+
+func (x *Tariff) GetDailyCapCents() int32 {
+	if x != nil {
+		return x.DailyCapCents
+	}
+	return 0
+}
+
+func (x *Tariff) GetNightMultiplier() float64 {
+	if x != nil {
+		return x.NightMultiplier
+	}
+	return 0
+}
+
+func (x *Tariff) GetWeekendMultiplier() float64 {
+	if x != nil {
+		return x.WeekendMultiplier
+	}
+	return 0
+}
+
+func (x *Tariff) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+func (x *Tariff) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CreateTariffRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BikeType       string `protobuf:"bytes,1,opt,name=bike_type,json=bikeType,proto3" json:"bike_type,omitempty"`
+	UnlockFeeCents int32  `protobuf:"varint,2,opt,name=unlock_fee_cents,json=unlockFeeCents,proto3" json:"unlock_fee_cents,omitempty"`
+	PerMinuteCents int32  `protobuf:"varint,3,opt,name=per_minute_cents,json=perMinuteCents,proto3" json:"per_minute_cents,omitempty"`
+	DailyCapCents  int32  `protobuf:"varint,4,opt,name=daily_cap_cents,json=dailyCapCents,proto3" json:"daily_cap_cents,omitempty"`
+	// 1 when 0
+	NightMultiplier   float64 `protobuf:"fixed64,5,opt,name=night_multiplier,json=nightMultiplier,proto3" json:"night_multiplier,omitempty"`
+	WeekendMultiplier float64 `protobuf:"fixed64,6,opt,name=weekend_multiplier,json=weekendMultiplier,proto3" json:"weekend_multiplier,omitempty"`
+	// now when unset
+	ValidFrom *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+}
+
+func (x *CreateTariffRequest) Reset() {
+	*x = CreateTariffRequest{}
+	mi := &file_pricing_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTariffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTariffRequest) ProtoMessage() {}
+
+func (x *CreateTariffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTariffRequest.ProtoReflect.Descriptor instead.
+func (*CreateTariffRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateTariffRequest) GetBikeType() string {
+	if x != nil {
+		return x.BikeType
+	}
+	return ""
+}
+
+func (x *CreateTariffRequest) GetUnlockFeeCents() int32 {
+	if x != nil {
+		return x.UnlockFeeCents
+	}
+	return 0
+}
+
+func (x *CreateTariffRequest) GetPerMinuteCents() int32 {
+	if x != nil {
+		return x.PerMinuteCents
+	}
+	return 0
+}
+
+func (x *CreateTariffRequest) GetDailyCapCents() int32 {
+	if x != nil {
+		return x.DailyCapCents
+	}
+	return 0
+}
+
+func (x *CreateTariffRequest) GetNightMultiplier() float64 {
+	if x != nil {
+		return x.NightMultiplier
+	}
+	return 0
+}
+
+func (x *CreateTariffRequest) GetWeekendMultiplier() float64 {
+	if x != nil {
+		return x.WeekendMultiplier
+	}
+	return 0
+}
+
+func (x *CreateTariffRequest) GetValidFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return nil
+}
+
+type ListTariffsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// all bike types when empty
+	BikeType string `protobuf:"bytes,1,opt,name=bike_type,json=bikeType,proto3" json:"bike_type,omitempty"`
+}
+
+func (x *ListTariffsRequest) Reset() {
+	*x = ListTariffsRequest{}
+	mi := &file_pricing_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTariffsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTariffsRequest) ProtoMessage() {}
+
+func (x *ListTariffsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTariffsRequest.ProtoReflect.Descriptor instead.
+func (*ListTariffsRequest) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListTariffsRequest) GetBikeType() string {
+	if x != nil {
+		return x.BikeType
+	}
+	return ""
+}
+
+type ListTariffsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tariffs []*Tariff `protobuf:"bytes,1,rep,name=tariffs,proto3" json:"tariffs,omitempty"`
+}
+
+func (x *ListTariffsReply) Reset() {
+	*x = ListTariffsReply{}
+	mi := &file_pricing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTariffsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTariffsReply) ProtoMessage() {}
+
+func (x *ListTariffsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_pricing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTariffsReply.ProtoReflect.Descriptor instead.
+func (*ListTariffsReply) Descriptor() ([]byte, []int) {
+	return file_pricing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListTariffsReply) GetTariffs() []*Tariff {
+	if x != nil {
+		return x.Tariffs
+	}
+	return nil
+}
+
+var File_pricing_proto protoreflect.FileDescriptor
+
+var file_pricing_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x70, 0x72, 0x69, 0x63, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x07, 0x70, 0x72, 0x69, 0x63, 0x69, 0x6e, 0x67, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9b, 0x03, 0x0a, 0x06, 0x54, 0x61, 0x72, 0x69,
+	0x66, 0x66, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x6b, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x69, 0x6b, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x75, 0x6e, 0x6c,
+	0x6f, 0x63, 0x6b, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0e, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x65, 0x65, 0x43, 0x65,
+	0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x65, 0x72, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74,
+	0x65, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x70,
+	0x65, 0x72, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26, 0x0a,
+	0x0f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x63, 0x61, 0x70, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x43, 0x61, 0x70,
+	0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x6e, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6d,
+	0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0f, 0x6e, 0x69, 0x67, 0x68, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72,
+	0x12, 0x2d, 0x0a, 0x12, 0x77, 0x65, 0x65, 0x6b, 0x65, 0x6e, 0x64, 0x5f, 0x6d, 0x75, 0x6c, 0x74,
+	0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x77, 0x65,
+	0x65, 0x6b, 0x65, 0x6e, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x12,
+	0x39, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xc3, 0x02, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x54, 0x61, 0x72, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x62, 0x69, 0x6b, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x62, 0x69, 0x6b, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x28, 0x0a, 0x10, 0x75, 0x6e,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x46, 0x65, 0x65, 0x43,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x70, 0x65, 0x72, 0x5f, 0x6d, 0x69, 0x6e, 0x75,
+	0x74, 0x65, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x70, 0x65, 0x72, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x26,
+	0x0a, 0x0f, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x5f, 0x63, 0x61, 0x70, 0x5f, 0x63, 0x65, 0x6e, 0x74,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x64, 0x61, 0x69, 0x6c, 0x79, 0x43, 0x61,
+	0x70, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x6e, 0x69, 0x67, 0x68, 0x74, 0x5f,
+	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0f, 0x6e, 0x69, 0x67, 0x68, 0x74, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65,
+	0x72, 0x12, 0x2d, 0x0a, 0x12, 0x77, 0x65, 0x65, 0x6b, 0x65, 0x6e, 0x64, 0x5f, 0x6d, 0x75, 0x6c,
+	0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x77,
+	0x65, 0x65, 0x6b, 0x65, 0x6e, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x70, 0x6c, 0x69, 0x65, 0x72,
+	0x12, 0x39, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x22, 0x31, 0x0a, 0x12, 0x4c,
+	0x69, 0x73, 0x74, 0x54, 0x61, 0x72, 0x69, 0x66, 0x66, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x6b, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x69, 0x6b, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x3d,
+	0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x72, 0x69, 0x66, 0x66, 0x73, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x74, 0x61, 0x72, 0x69, 0x66, 0x66, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x61,
+	0x72, 0x69, 0x66, 0x66, 0x52, 0x07, 0x74, 0x61, 0x72, 0x69, 0x66, 0x66, 0x73, 0x32, 0xc3, 0x01,
+	0x0a, 0x0e, 0x50, 0x72, 0x69, 0x63, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x55, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x72, 0x69, 0x66, 0x66,
+	0x12, 0x1c, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x69, 0x6e, 0x67, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x54, 0x61, 0x72, 0x69, 0x66, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f,
+	0x2e, 0x70, 0x72, 0x69, 0x63, 0x69, 0x6e, 0x67, 0x2e, 0x54, 0x61, 0x72, 0x69, 0x66, 0x66, 0x22,
+	0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x3a, 0x01, 0x2a, 0x22, 0x0b, 0x2f, 0x76, 0x31, 0x2f,
+	0x74, 0x61, 0x72, 0x69, 0x66, 0x66, 0x73, 0x12, 0x5a, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x54,
+	0x61, 0x72, 0x69, 0x66, 0x66, 0x73, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x69, 0x6e, 0x67,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x72, 0x69, 0x66, 0x66, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x72, 0x69, 0x63, 0x69, 0x6e, 0x67, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x54, 0x61, 0x72, 0x69, 0x66, 0x66, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x13,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x12, 0x0b, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x61, 0x72, 0x69,
+	0x66, 0x66, 0x73, 0x42, 0x10, 0x5a, 0x0e, 0x70, 0x72, 0x69, 0x63, 0x69, 0x6e, 0x67, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_pricing_proto_rawDescOnce sync.Once
+	file_pricing_proto_rawDescData = file_pricing_proto_rawDesc
+)
+
+func file_pricing_proto_rawDescGZIP() []byte {
+	file_pricing_proto_rawDescOnce.Do(func() {
+		file_pricing_proto_rawDescData = protoimpl.X.CompressGZIP(file_pricing_proto_rawDescData)
+	})
+	return file_pricing_proto_rawDescData
+}
+
+var file_pricing_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_pricing_proto_goTypes = []any{
+	(*Tariff)(nil),                // 0: pricing.Tariff
+	(*CreateTariffRequest)(nil),   // 1: pricing.CreateTariffRequest
+	(*ListTariffsRequest)(nil),    // 2: pricing.ListTariffsRequest
+	(*ListTariffsReply)(nil),      // 3: pricing.ListTariffsReply
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
+}
+var file_pricing_proto_depIdxs = []int32{
+	4, // 0: pricing.Tariff.valid_from:type_name -> google.protobuf.Timestamp
+	4, // 1: pricing.Tariff.created_at:type_name -> google.protobuf.Timestamp
+	4, // 2: pricing.CreateTariffRequest.valid_from:type_name -> google.protobuf.Timestamp
+	0, // 3: pricing.ListTariffsReply.tariffs:type_name -> pricing.Tariff
+	1, // 4: pricing.PricingService.CreateTariff:input_type -> pricing.CreateTariffRequest
+	2, // 5: pricing.PricingService.ListTariffs:input_type -> pricing.ListTariffsRequest
+	0, // 6: pricing.PricingService.CreateTariff:output_type -> pricing.Tariff
+	3, // 7: pricing.PricingService.ListTariffs:output_type -> pricing.ListTariffsReply
+	6, // [6:8] is the sub-list for method output_type
+	4, // [4:6] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_pricing_proto_init() }
+func file_pricing_proto_init() {
+	if File_pricing_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_pricing_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pricing_proto_goTypes,
+		DependencyIndexes: file_pricing_proto_depIdxs,
+		MessageInfos:      file_pricing_proto_msgTypes,
+	}.Build()
+	File_pricing_proto = out.File
+	file_pricing_proto_rawDesc = nil
+	file_pricing_proto_goTypes = nil
+	file_pricing_proto_depIdxs = nil
+}
@@ -0,0 +1,169 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: pricing.proto
+
+package backend
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	PricingService_CreateTariff_FullMethodName = "/pricing.PricingService/CreateTariff"
+	PricingService_ListTariffs_FullMethodName  = "/pricing.PricingService/ListTariffs"
+)
+
+// PricingServiceClient is the client API for PricingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PricingService manages the tariffs rentals are charged by. Anyone can
+// read them, only admins can publish new versions.
+type PricingServiceClient interface {
+	// Publish a new version of the tariff for a bike type
+	CreateTariff(ctx context.Context, in *CreateTariffRequest, opts ...grpc.CallOption) (*Tariff, error)
+	// List tariff versions, newest first
+	ListTariffs(ctx context.Context, in *ListTariffsRequest, opts ...grpc.CallOption) (*ListTariffsReply, error)
+}
+
+type pricingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPricingServiceClient(cc grpc.ClientConnInterface) PricingServiceClient {
+	return &pricingServiceClient{cc}
+}
+
+func (c *pricingServiceClient) CreateTariff(ctx context.Context, in *CreateTariffRequest, opts ...grpc.CallOption) (*Tariff, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tariff)
+	err := c.cc.Invoke(ctx, PricingService_CreateTariff_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) ListTariffs(ctx context.Context, in *ListTariffsRequest, opts ...grpc.CallOption) (*ListTariffsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTariffsReply)
+	err := c.cc.Invoke(ctx, PricingService_ListTariffs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PricingServiceServer is the server API for PricingService service.
+// All implementations must embed UnimplementedPricingServiceServer
+// for forward compatibility.
+//
+// PricingService manages the tariffs rentals are charged by. Anyone can
+// read them, only admins can publish new versions.
+type PricingServiceServer interface {
+	// Publish a new version of the tariff for a bike type
+	CreateTariff(context.Context, *CreateTariffRequest) (*Tariff, error)
+	// List tariff versions, newest first
+	ListTariffs(context.Context, *ListTariffsRequest) (*ListTariffsReply, error)
+	mustEmbedUnimplementedPricingServiceServer()
+}
+
+// UnimplementedPricingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPricingServiceServer struct{}
+
+func (UnimplementedPricingServiceServer) CreateTariff(context.Context, *CreateTariffRequest) (*Tariff, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTariff not implemented")
+}
+func (UnimplementedPricingServiceServer) ListTariffs(context.Context, *ListTariffsRequest) (*ListTariffsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTariffs not implemented")
+}
+func (UnimplementedPricingServiceServer) mustEmbedUnimplementedPricingServiceServer() {}
+func (UnimplementedPricingServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafePricingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PricingServiceServer will
+// result in compilation errors.
+type UnsafePricingServiceServer interface {
+	mustEmbedUnimplementedPricingServiceServer()
+}
+
+func RegisterPricingServiceServer(s grpc.ServiceRegistrar, srv PricingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPricingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PricingService_ServiceDesc, srv)
+}
+
+func _PricingService_CreateTariff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTariffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).CreateTariff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_CreateTariff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).CreateTariff(ctx, req.(*CreateTariffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_ListTariffs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTariffsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).ListTariffs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_ListTariffs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).ListTariffs(ctx, req.(*ListTariffsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PricingService_ServiceDesc is the grpc.ServiceDesc for PricingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PricingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pricing.PricingService",
+	HandlerType: (*PricingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTariff",
+			Handler:    _PricingService_CreateTariff_Handler,
+		},
+		{
+			MethodName: "ListTariffs",
+			Handler:    _PricingService_ListTariffs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pricing.proto",
+}
@@ -0,0 +1,231 @@
+package backend
+
+import (
+	"backend/auth"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	RoleRider    = "rider"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+var (
+	anyRole   = []string{RoleRider, RoleOperator, RoleAdmin}
+	staffRole = []string{RoleOperator, RoleAdmin}
+	adminRole = []string{RoleAdmin}
+)
+
+// Permissions lists the roles allowed to call each authenticated RPC.
+// Methods missing from the table are denied to everyone, so new RPCs have
+// to be added here explicitly. Per-record checks, such as riders only
+// touching their own rentals, happen in the servers themselves.
+var Permissions = map[string][]string{
+	"/authenticator.Auth/SampleProtected":         anyRole,
+	"/authenticator.Auth/UnlockAccount":           adminRole,
+	"/authenticator.Auth/EnrollMFA":               anyRole,
+	"/authenticator.Auth/ConfirmMFA":              anyRole,
+	"/authenticator.Auth/ResendVerificationEmail": anyRole,
+
+	"/user.UserService/GetMe":         anyRole,
+	"/user.UserService/UpdateMe":      anyRole,
+	"/user.UserService/DeleteMe":      anyRole,
+	"/user.UserService/ListSessions":  anyRole,
+	"/user.UserService/RevokeSession": anyRole,
+
+	"/admin.AdminService/ListUsers":   adminRole,
+	"/admin.AdminService/GetUser":     adminRole,
+	"/admin.AdminService/DisableUser": adminRole,
+	"/admin.AdminService/EnableUser":  adminRole,
+	"/admin.AdminService/SetRole":     adminRole,
+
+	"/apikey.ApiKeyService/CreateApiKey": anyRole,
+	"/apikey.ApiKeyService/ListApiKeys":  anyRole,
+	"/apikey.ApiKeyService/RevokeApiKey": anyRole,
+
+	"/pricing.PricingService/ListTariffs":  anyRole,
+	"/pricing.PricingService/CreateTariff": adminRole,
+
+	"/station.StationService/GetStation":    anyRole,
+	"/station.StationService/ListStations":  anyRole,
+	"/station.StationService/SearchNearby":  anyRole,
+	"/station.StationService/CreateStation": staffRole,
+	"/station.StationService/UpdateStation": staffRole,
+	"/station.StationService/DeleteStation": staffRole,
+
+	"/bikerental.BikeService/GetBike":             anyRole,
+	"/bikerental.BikeService/GetBikeAvailability": anyRole,
+	"/bikerental.BikeService/ListBikes":           anyRole,
+	"/bikerental.BikeService/CreateBike":          staffRole,
+	"/bikerental.BikeService/UpdateBike":          staffRole,
+	"/bikerental.BikeService/DeleteBike":          staffRole,
+
+	"/bikerental.RentalService/ReserveBike":       anyRole,
+	"/bikerental.RentalService/GetReservation":    anyRole,
+	"/bikerental.RentalService/CancelReservation": anyRole,
+	"/bikerental.RentalService/QuoteRental":       anyRole,
+	"/bikerental.RentalService/CreateRental":      anyRole,
+	"/bikerental.RentalService/GetRental":         anyRole,
+	"/bikerental.RentalService/UpdateRental":      staffRole,
+	"/bikerental.RentalService/EndRental":         anyRole,
+	"/bikerental.RentalService/DeleteRental":      staffRole,
+	"/bikerental.RentalService/ListRentals":       anyRole,
+	"/bikerental.RentalService/ListMyRentals":     anyRole,
+	"/bikerental.RentalService/CreateBooking":     anyRole,
+	"/bikerental.RentalService/CancelBooking":     anyRole,
+}
+
+func ValidRole(role string) bool {
+	for _, r := range anyRole {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// lowerRole returns whichever of a and b grants less. Unknown roles grant
+// the least, so they are never widened to a valid one.
+func lowerRole(a, b string) string {
+	rank := func(role string) int {
+		for i, r := range anyRole {
+			if r == role {
+				return i
+			}
+		}
+		return -1
+	}
+	if rank(a) <= rank(b) {
+		return a
+	}
+	return b
+}
+
+// IsStaff reports whether role may act on other users' records.
+func IsStaff(role string) bool {
+	return role == RoleOperator || role == RoleAdmin
+}
+
+// Authorize checks role against the Permissions table for fullMethod.
+func Authorize(fullMethod string, role string) error {
+	for _, allowed := range Permissions[fullMethod] {
+		if allowed == role {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "role %q may not call %s", role, fullMethod)
+}
+
+// API key scopes.
+const (
+	ScopeBikesRead     = "bikes:read"
+	ScopeBikesWrite    = "bikes:write"
+	ScopeRentalsRead   = "rentals:read"
+	ScopeRentalsWrite  = "rentals:write"
+	ScopeStationsRead  = "stations:read"
+	ScopeStationsWrite = "stations:write"
+	ScopeProfileRead   = "profile:read"
+)
+
+// MethodScopes lists the scope an API key needs for each RPC, on top of
+// the role check in Permissions. RPCs missing here, such as managing
+// passwords or API keys, cannot be called with an API key at all.
+var MethodScopes = map[string]string{
+	"/user.UserService/GetMe": ScopeProfileRead,
+
+	"/bikerental.BikeService/GetBike":             ScopeBikesRead,
+	"/bikerental.BikeService/GetBikeAvailability": ScopeBikesRead,
+	"/bikerental.BikeService/ListBikes":           ScopeBikesRead,
+	"/bikerental.BikeService/CreateBike":          ScopeBikesWrite,
+	"/bikerental.BikeService/UpdateBike":          ScopeBikesWrite,
+	"/bikerental.BikeService/DeleteBike":          ScopeBikesWrite,
+
+	"/station.StationService/GetStation":    ScopeStationsRead,
+	"/station.StationService/ListStations":  ScopeStationsRead,
+	"/station.StationService/SearchNearby":  ScopeStationsRead,
+	"/station.StationService/CreateStation": ScopeStationsWrite,
+	"/station.StationService/UpdateStation": ScopeStationsWrite,
+	"/station.StationService/DeleteStation": ScopeStationsWrite,
+
+	"/bikerental.RentalService/ReserveBike":       ScopeRentalsWrite,
+	"/bikerental.RentalService/GetReservation":    ScopeRentalsRead,
+	"/bikerental.RentalService/CancelReservation": ScopeRentalsWrite,
+	"/bikerental.RentalService/QuoteRental":       ScopeRentalsRead,
+	"/bikerental.RentalService/CreateRental":      ScopeRentalsWrite,
+	"/bikerental.RentalService/GetRental":         ScopeRentalsRead,
+	"/bikerental.RentalService/UpdateRental":      ScopeRentalsWrite,
+	"/bikerental.RentalService/EndRental":         ScopeRentalsWrite,
+	"/bikerental.RentalService/DeleteRental":      ScopeRentalsWrite,
+	"/bikerental.RentalService/ListRentals":       ScopeRentalsRead,
+	"/bikerental.RentalService/ListMyRentals":     ScopeRentalsRead,
+	"/bikerental.RentalService/CreateBooking":     ScopeRentalsWrite,
+	"/bikerental.RentalService/CancelBooking":     ScopeRentalsWrite,
+}
+
+// ValidScope reports whether scope is one of the known API key scopes.
+func ValidScope(scope string) bool {
+	for _, s := range MethodScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireStaffMFA makes staff-only RPCs, such as deleting bikes, require
+// an access token issued after a second factor was verified. Override with
+// REQUIRE_STAFF_MFA.
+var RequireStaffMFA = boolFromEnv("REQUIRE_STAFF_MFA", true)
+
+// AuthorizeIdentity adapts Authorize to auth.Authorizer and enforces
+// RequireStaffMFA.
+func AuthorizeIdentity(fullMethod string, id *auth.Identity) error {
+	if err := Authorize(fullMethod, id.Role); err != nil {
+		return err
+	}
+	// API keys are limited by their scopes instead of a second factor;
+	// creating a staff key already required one
+	if id.APIKeyID != 0 {
+		return authorizeScope(fullMethod, id.Scopes)
+	}
+	if RequireStaffMFA && staffOnly(fullMethod) && !id.MFA {
+		return status.Errorf(codes.PermissionDenied, "%s requires two-factor authentication, enroll with EnrollMFA and log in again", fullMethod)
+	}
+	return nil
+}
+
+func authorizeScope(fullMethod string, scopes []string) error {
+	required, ok := MethodScopes[fullMethod]
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "%s cannot be called with an API key", fullMethod)
+	}
+	for _, scope := range scopes {
+		if scope == required {
+			return nil
+		}
+	}
+	return status.Errorf(codes.PermissionDenied, "API key lacks the %q scope", required)
+}
+
+// staffOnly reports whether riders are barred from fullMethod.
+func staffOnly(fullMethod string) bool {
+	for _, allowed := range Permissions[fullMethod] {
+		if allowed == RoleRider {
+			return false
+		}
+	}
+	return true
+}
+
+// CurrentRole returns the role of the authenticated caller.
+func CurrentRole(ctx context.Context) string {
+	id, ok := auth.FromContext(ctx)
+	if !ok || id.Role == "" {
+		return RoleRider
+	}
+	return id.Role
+}
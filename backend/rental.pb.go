@@ -22,6 +22,70 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// BikeStatus is the lifecycle state of a bike. RENTED and RESERVED are
+// managed by rentals; the allowed transitions are listed in
+// backend/bike_status.go.
+type BikeStatus int32
+
+const (
+	BikeStatus_BIKE_STATUS_UNSPECIFIED BikeStatus = 0
+	BikeStatus_AVAILABLE               BikeStatus = 1
+	BikeStatus_RENTED                  BikeStatus = 2
+	BikeStatus_RESERVED                BikeStatus = 3
+	BikeStatus_MAINTENANCE             BikeStatus = 4
+	BikeStatus_RETIRED                 BikeStatus = 5
+	BikeStatus_LOST                    BikeStatus = 6
+)
+
+// Enum value maps for BikeStatus.
+var (
+	BikeStatus_name = map[int32]string{
+		0: "BIKE_STATUS_UNSPECIFIED",
+		1: "AVAILABLE",
+		2: "RENTED",
+		3: "RESERVED",
+		4: "MAINTENANCE",
+		5: "RETIRED",
+		6: "LOST",
+	}
+	BikeStatus_value = map[string]int32{
+		"BIKE_STATUS_UNSPECIFIED": 0,
+		"AVAILABLE":               1,
+		"RENTED":                  2,
+		"RESERVED":                3,
+		"MAINTENANCE":             4,
+		"RETIRED":                 5,
+		"LOST":                    6,
+	}
+)
+
+func (x BikeStatus) Enum() *BikeStatus {
+	p := new(BikeStatus)
+	*p = x
+	return p
+}
+
+func (x BikeStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BikeStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_rental_proto_enumTypes[0].Descriptor()
+}
+
+func (BikeStatus) Type() protoreflect.EnumType {
+	return &file_rental_proto_enumTypes[0]
+}
+
+func (x BikeStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BikeStatus.Descriptor instead.
+func (BikeStatus) EnumDescriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{0}
+}
+
 type Bike struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -29,9 +93,16 @@ type Bike struct {
 
 	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Model     string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
-	Status    string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Status    BikeStatus             `protobuf:"varint,10,opt,name=status,proto3,enum=bikerental.BikeStatus" json:"status,omitempty"`
 	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// selects the tariff, "standard" by default
+	Type string `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"`
+	// 0 while the bike is rented or away from any station
+	StationId int32 `protobuf:"varint,7,opt,name=station_id,json=stationId,proto3" json:"station_id,omitempty"`
+	DockId    int32 `protobuf:"varint,8,opt,name=dock_id,json=dockId,proto3" json:"dock_id,omitempty"`
+	// percent, only known for electric bikes
+	BatteryLevel *int32 `protobuf:"varint,9,opt,name=battery_level,json=batteryLevel,proto3,oneof" json:"battery_level,omitempty"`
 }
 
 func (x *Bike) Reset() {
@@ -78,11 +149,11 @@ func (x *Bike) GetModel() string {
 	return ""
 }
 
-func (x *Bike) GetStatus() string {
+func (x *Bike) GetStatus() BikeStatus {
 	if x != nil {
 		return x.Status
 	}
-	return ""
+	return BikeStatus_BIKE_STATUS_UNSPECIFIED
 }
 
 func (x *Bike) GetCreatedAt() *timestamppb.Timestamp {
@@ -99,6 +170,34 @@ func (x *Bike) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Bike) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Bike) GetStationId() int32 {
+	if x != nil {
+		return x.StationId
+	}
+	return 0
+}
+
+func (x *Bike) GetDockId() int32 {
+	if x != nil {
+		return x.DockId
+	}
+	return 0
+}
+
+func (x *Bike) GetBatteryLevel() int32 {
+	if x != nil && x.BatteryLevel != nil {
+		return *x.BatteryLevel
+	}
+	return 0
+}
+
 type Rental struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -110,6 +209,13 @@ type Rental struct {
 	StartTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
 	EndTime   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
 	Status    string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// set once the rental has ended
+	DurationSeconds int32 `protobuf:"varint,7,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	CostCents       int32 `protobuf:"varint,8,opt,name=cost_cents,json=costCents,proto3" json:"cost_cents,omitempty"`
+	ReturnStationId int32 `protobuf:"varint,9,opt,name=return_station_id,json=returnStationId,proto3" json:"return_station_id,omitempty"`
+	// the tariff the rental was priced with
+	TariffId       int32 `protobuf:"varint,10,opt,name=tariff_id,json=tariffId,proto3" json:"tariff_id,omitempty"`
+	StartStationId int32 `protobuf:"varint,11,opt,name=start_station_id,json=startStationId,proto3" json:"start_station_id,omitempty"`
 }
 
 func (x *Rental) Reset() {
@@ -184,28 +290,72 @@ func (x *Rental) GetStatus() string {
 	return ""
 }
 
-type DeletedRentalResponse struct {
+func (x *Rental) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *Rental) GetCostCents() int32 {
+	if x != nil {
+		return x.CostCents
+	}
+	return 0
+}
+
+func (x *Rental) GetReturnStationId() int32 {
+	if x != nil {
+		return x.ReturnStationId
+	}
+	return 0
+}
+
+func (x *Rental) GetTariffId() int32 {
+	if x != nil {
+		return x.TariffId
+	}
+	return 0
+}
+
+func (x *Rental) GetStartStationId() int32 {
+	if x != nil {
+		return x.StartStationId
+	}
+	return 0
+}
+
+type Reservation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Id     int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId int32 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BikeId int32 `protobuf:"varint,3,opt,name=bike_id,json=bikeId,proto3" json:"bike_id,omitempty"`
+	// ACTIVE, CANCELLED, EXPIRED or CONVERTED
+	Status    string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	EndedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	// the rental it was converted into
+	RentalId int32 `protobuf:"varint,8,opt,name=rental_id,json=rentalId,proto3" json:"rental_id,omitempty"`
 }
 
-func (x *DeletedRentalResponse) Reset() {
-	*x = DeletedRentalResponse{}
+func (x *Reservation) Reset() {
+	*x = Reservation{}
 	mi := &file_rental_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeletedRentalResponse) String() string {
+func (x *Reservation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletedRentalResponse) ProtoMessage() {}
+func (*Reservation) ProtoMessage() {}
 
-func (x *DeletedRentalResponse) ProtoReflect() protoreflect.Message {
+func (x *Reservation) ProtoReflect() protoreflect.Message {
 	mi := &file_rental_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -217,42 +367,96 @@ func (x *DeletedRentalResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletedRentalResponse.ProtoReflect.Descriptor instead.
-func (*DeletedRentalResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Reservation.ProtoReflect.Descriptor instead.
+func (*Reservation) Descriptor() ([]byte, []int) {
 	return file_rental_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *DeletedRentalResponse) GetMessage() string {
+func (x *Reservation) GetId() int32 {
 	if x != nil {
-		return x.Message
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Reservation) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Reservation) GetBikeId() int32 {
+	if x != nil {
+		return x.BikeId
+	}
+	return 0
+}
+
+func (x *Reservation) GetStatus() string {
+	if x != nil {
+		return x.Status
 	}
 	return ""
 }
 
-// Bike Operations
-type CreateBikeRequest struct {
+func (x *Reservation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *Reservation) GetRentalId() int32 {
+	if x != nil {
+		return x.RentalId
+	}
+	return 0
+}
+
+type Booking struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Model  string `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
-	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Id        int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId    int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BikeId    int32                  `protobuf:"varint,3,opt,name=bike_id,json=bikeId,proto3" json:"bike_id,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// CONFIRMED or CANCELLED
+	Status    string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 }
 
-func (x *CreateBikeRequest) Reset() {
-	*x = CreateBikeRequest{}
+func (x *Booking) Reset() {
+	*x = Booking{}
 	mi := &file_rental_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateBikeRequest) String() string {
+func (x *Booking) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateBikeRequest) ProtoMessage() {}
+func (*Booking) ProtoMessage() {}
 
-func (x *CreateBikeRequest) ProtoReflect() protoreflect.Message {
+func (x *Booking) ProtoReflect() protoreflect.Message {
 	mi := &file_rental_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -264,47 +468,85 @@ func (x *CreateBikeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateBikeRequest.ProtoReflect.Descriptor instead.
-func (*CreateBikeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Booking.ProtoReflect.Descriptor instead.
+func (*Booking) Descriptor() ([]byte, []int) {
 	return file_rental_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CreateBikeRequest) GetModel() string {
+func (x *Booking) GetId() int32 {
 	if x != nil {
-		return x.Model
+		return x.Id
 	}
-	return ""
+	return 0
+}
+
+func (x *Booking) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Booking) GetBikeId() int32 {
+	if x != nil {
+		return x.BikeId
+	}
+	return 0
+}
+
+func (x *Booking) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Booking) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
 }
 
-func (x *CreateBikeRequest) GetStatus() string {
+func (x *Booking) GetStatus() string {
 	if x != nil {
 		return x.Status
 	}
 	return ""
 }
 
-type GetBikeRequest struct {
+func (x *Booking) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type TimeSlot struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// BOOKING, RENTAL or RESERVATION for busy slots, empty for free ones
+	Kind string `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`
 }
 
-func (x *GetBikeRequest) Reset() {
-	*x = GetBikeRequest{}
+func (x *TimeSlot) Reset() {
+	*x = TimeSlot{}
 	mi := &file_rental_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBikeRequest) String() string {
+func (x *TimeSlot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBikeRequest) ProtoMessage() {}
+func (*TimeSlot) ProtoMessage() {}
 
-func (x *GetBikeRequest) ProtoReflect() protoreflect.Message {
+func (x *TimeSlot) ProtoReflect() protoreflect.Message {
 	mi := &file_rental_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -316,43 +558,829 @@ func (x *GetBikeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBikeRequest.ProtoReflect.Descriptor instead.
-func (*GetBikeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TimeSlot.ProtoReflect.Descriptor instead.
+func (*TimeSlot) Descriptor() ([]byte, []int) {
 	return file_rental_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *GetBikeRequest) GetId() int32 {
+func (x *TimeSlot) GetStartTime() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *TimeSlot) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *TimeSlot) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+type BikeAvailability struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BikeId int32 `protobuf:"varint,1,opt,name=bike_id,json=bikeId,proto3" json:"bike_id,omitempty"`
+	// the bike's status now, a RENTED bike is busy until its rental ends
+	Status BikeStatus  `protobuf:"varint,2,opt,name=status,proto3,enum=bikerental.BikeStatus" json:"status,omitempty"`
+	Busy   []*TimeSlot `protobuf:"bytes,3,rep,name=busy,proto3" json:"busy,omitempty"`
+	// empty while the bike is in MAINTENANCE, RETIRED or LOST
+	Free []*TimeSlot `protobuf:"bytes,4,rep,name=free,proto3" json:"free,omitempty"`
+}
+
+func (x *BikeAvailability) Reset() {
+	*x = BikeAvailability{}
+	mi := &file_rental_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BikeAvailability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BikeAvailability) ProtoMessage() {}
+
+func (x *BikeAvailability) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BikeAvailability.ProtoReflect.Descriptor instead.
+func (*BikeAvailability) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BikeAvailability) GetBikeId() int32 {
+	if x != nil {
+		return x.BikeId
 	}
 	return 0
 }
 
-type UpdateBikeRequest struct {
+func (x *BikeAvailability) GetStatus() BikeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BikeStatus_BIKE_STATUS_UNSPECIFIED
+}
+
+func (x *BikeAvailability) GetBusy() []*TimeSlot {
+	if x != nil {
+		return x.Busy
+	}
+	return nil
+}
+
+func (x *BikeAvailability) GetFree() []*TimeSlot {
+	if x != nil {
+		return x.Free
+	}
+	return nil
+}
+
+type DeletedRentalResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *DeletedRentalResponse) Reset() {
+	*x = DeletedRentalResponse{}
+	mi := &file_rental_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletedRentalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletedRentalResponse) ProtoMessage() {}
+
+func (x *DeletedRentalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletedRentalResponse.ProtoReflect.Descriptor instead.
+func (*DeletedRentalResponse) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeletedRentalResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Bike Operations
+type CreateBikeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Model string `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	// AVAILABLE when unspecified
+	Status BikeStatus `protobuf:"varint,5,opt,name=status,proto3,enum=bikerental.BikeStatus" json:"status,omitempty"`
+	// "standard" when empty
+	Type string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	// docks the bike, which also places it at the dock's station
+	DockId int32 `protobuf:"varint,4,opt,name=dock_id,json=dockId,proto3" json:"dock_id,omitempty"`
+}
+
+func (x *CreateBikeRequest) Reset() {
+	*x = CreateBikeRequest{}
+	mi := &file_rental_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBikeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBikeRequest) ProtoMessage() {}
+
+func (x *CreateBikeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBikeRequest.ProtoReflect.Descriptor instead.
+func (*CreateBikeRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateBikeRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CreateBikeRequest) GetStatus() BikeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BikeStatus_BIKE_STATUS_UNSPECIFIED
+}
+
+func (x *CreateBikeRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreateBikeRequest) GetDockId() int32 {
+	if x != nil {
+		return x.DockId
+	}
+	return 0
+}
+
+type GetBikeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetBikeRequest) Reset() {
+	*x = GetBikeRequest{}
+	mi := &file_rental_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBikeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBikeRequest) ProtoMessage() {}
+
+func (x *GetBikeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBikeRequest.ProtoReflect.Descriptor instead.
+func (*GetBikeRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetBikeRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type UpdateBikeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Model string `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	// left unchanged when unspecified
+	Status BikeStatus `protobuf:"varint,7,opt,name=status,proto3,enum=bikerental.BikeStatus" json:"status,omitempty"`
+	Type   string     `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// moves the bike into this dock, left unchanged when 0
+	DockId       int32  `protobuf:"varint,5,opt,name=dock_id,json=dockId,proto3" json:"dock_id,omitempty"`
+	BatteryLevel *int32 `protobuf:"varint,6,opt,name=battery_level,json=batteryLevel,proto3,oneof" json:"battery_level,omitempty"`
+}
+
+func (x *UpdateBikeRequest) Reset() {
+	*x = UpdateBikeRequest{}
+	mi := &file_rental_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBikeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBikeRequest) ProtoMessage() {}
+
+func (x *UpdateBikeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBikeRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBikeRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateBikeRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateBikeRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *UpdateBikeRequest) GetStatus() BikeStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BikeStatus_BIKE_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateBikeRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *UpdateBikeRequest) GetDockId() int32 {
+	if x != nil {
+		return x.DockId
+	}
+	return 0
+}
+
+func (x *UpdateBikeRequest) GetBatteryLevel() int32 {
+	if x != nil && x.BatteryLevel != nil {
+		return *x.BatteryLevel
+	}
+	return 0
+}
+
+type DeleteBikeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteBikeRequest) Reset() {
+	*x = DeleteBikeRequest{}
+	mi := &file_rental_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBikeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBikeRequest) ProtoMessage() {}
+
+func (x *DeleteBikeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBikeRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBikeRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DeleteBikeRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListBikesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Page     int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// AIP-160 filter joined with AND, e.g.
+	// status = AVAILABLE AND model:"urban" AND create_time >= "2025-01-01T00:00:00Z".
+	// Fields: status, type, model, station_id, create_time.
+	Filter string `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// AIP-132 order, e.g. "create_time desc, model"; id when empty.
+	// Fields: id, model, type, status, create_time, update_time.
+	OrderBy string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+}
+
+func (x *ListBikesRequest) Reset() {
+	*x = ListBikesRequest{}
+	mi := &file_rental_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBikesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBikesRequest) ProtoMessage() {}
+
+func (x *ListBikesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBikesRequest.ProtoReflect.Descriptor instead.
+func (*ListBikesRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListBikesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBikesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBikesRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListBikesRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+type GetBikeAvailabilityRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BikeId int32 `protobuf:"varint,1,opt,name=bike_id,json=bikeId,proto3" json:"bike_id,omitempty"`
+	// now when unset
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// a day after start_time when unset
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+}
+
+func (x *GetBikeAvailabilityRequest) Reset() {
+	*x = GetBikeAvailabilityRequest{}
+	mi := &file_rental_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBikeAvailabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBikeAvailabilityRequest) ProtoMessage() {}
+
+func (x *GetBikeAvailabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBikeAvailabilityRequest.ProtoReflect.Descriptor instead.
+func (*GetBikeAvailabilityRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetBikeAvailabilityRequest) GetBikeId() int32 {
+	if x != nil {
+		return x.BikeId
+	}
+	return 0
+}
+
+func (x *GetBikeAvailabilityRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *GetBikeAvailabilityRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type ListBikesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bikes []*Bike `protobuf:"bytes,1,rep,name=bikes,proto3" json:"bikes,omitempty"`
+	// number of bikes matching the filter across all pages
+	TotalSize int32 `protobuf:"varint,2,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+}
+
+func (x *ListBikesResponse) Reset() {
+	*x = ListBikesResponse{}
+	mi := &file_rental_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBikesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBikesResponse) ProtoMessage() {}
+
+func (x *ListBikesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBikesResponse.ProtoReflect.Descriptor instead.
+func (*ListBikesResponse) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListBikesResponse) GetBikes() []*Bike {
+	if x != nil {
+		return x.Bikes
+	}
+	return nil
+}
+
+func (x *ListBikesResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
+
+type DeletedBikeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Messsage string `protobuf:"bytes,1,opt,name=messsage,proto3" json:"messsage,omitempty"`
+}
+
+func (x *DeletedBikeResponse) Reset() {
+	*x = DeletedBikeResponse{}
+	mi := &file_rental_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletedBikeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletedBikeResponse) ProtoMessage() {}
+
+func (x *DeletedBikeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletedBikeResponse.ProtoReflect.Descriptor instead.
+func (*DeletedBikeResponse) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DeletedBikeResponse) GetMesssage() string {
+	if x != nil {
+		return x.Messsage
+	}
+	return ""
+}
+
+// Rental Operations
+type CreateRentalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// a bike the caller reserved converts the reservation
+	BikeId int32 `protobuf:"varint,1,opt,name=bike_id,json=bikeId,proto3" json:"bike_id,omitempty"`
+}
+
+func (x *CreateRentalRequest) Reset() {
+	*x = CreateRentalRequest{}
+	mi := &file_rental_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRentalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRentalRequest) ProtoMessage() {}
+
+func (x *CreateRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRentalRequest.ProtoReflect.Descriptor instead.
+func (*CreateRentalRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateRentalRequest) GetBikeId() int32 {
+	if x != nil {
+		return x.BikeId
+	}
+	return 0
+}
+
+type GetRentalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetRentalRequest) Reset() {
+	*x = GetRentalRequest{}
+	mi := &file_rental_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRentalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRentalRequest) ProtoMessage() {}
+
+func (x *GetRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRentalRequest.ProtoReflect.Descriptor instead.
+func (*GetRentalRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetRentalRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Staff correct the end time of completed rentals with it, ongoing ones
+// are ended with EndRental.
+type UpdateRentalRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ignored, the bike of a rental cannot change
+	//
+	// Deprecated: Marked as deprecated in rental.proto.
+	BikeId int32 `protobuf:"varint,3,opt,name=bike_id,json=bikeId,proto3" json:"bike_id,omitempty"`
+	// reprices the rental, left unchanged when unset
+	EndTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// COMPLETED or empty
+	Status string `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *UpdateRentalRequest) Reset() {
+	*x = UpdateRentalRequest{}
+	mi := &file_rental_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRentalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRentalRequest) ProtoMessage() {}
+
+func (x *UpdateRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRentalRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRentalRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateRentalRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in rental.proto.
+func (x *UpdateRentalRequest) GetBikeId() int32 {
+	if x != nil {
+		return x.BikeId
+	}
+	return 0
+}
+
+func (x *UpdateRentalRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+func (x *UpdateRentalRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type EndRentalRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id     int32  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Model  string `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
-	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	RentalId        int32 `protobuf:"varint,1,opt,name=rental_id,json=rentalId,proto3" json:"rental_id,omitempty"`
+	ReturnStationId int32 `protobuf:"varint,2,opt,name=return_station_id,json=returnStationId,proto3" json:"return_station_id,omitempty"`
+	// the dock the bike was put into, which implies its station
+	ReturnDockId int32 `protobuf:"varint,3,opt,name=return_dock_id,json=returnDockId,proto3" json:"return_dock_id,omitempty"`
 }
 
-func (x *UpdateBikeRequest) Reset() {
-	*x = UpdateBikeRequest{}
-	mi := &file_rental_proto_msgTypes[5]
+func (x *EndRentalRequest) Reset() {
+	*x = EndRentalRequest{}
+	mi := &file_rental_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateBikeRequest) String() string {
+func (x *EndRentalRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBikeRequest) ProtoMessage() {}
+func (*EndRentalRequest) ProtoMessage() {}
 
-func (x *UpdateBikeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rental_proto_msgTypes[5]
+func (x *EndRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -363,55 +1391,57 @@ func (x *UpdateBikeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBikeRequest.ProtoReflect.Descriptor instead.
-func (*UpdateBikeRequest) Descriptor() ([]byte, []int) {
-	return file_rental_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use EndRentalRequest.ProtoReflect.Descriptor instead.
+func (*EndRentalRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *UpdateBikeRequest) GetId() int32 {
+func (x *EndRentalRequest) GetRentalId() int32 {
 	if x != nil {
-		return x.Id
+		return x.RentalId
 	}
 	return 0
 }
 
-func (x *UpdateBikeRequest) GetModel() string {
+func (x *EndRentalRequest) GetReturnStationId() int32 {
 	if x != nil {
-		return x.Model
+		return x.ReturnStationId
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateBikeRequest) GetStatus() string {
+func (x *EndRentalRequest) GetReturnDockId() int32 {
 	if x != nil {
-		return x.Status
+		return x.ReturnDockId
 	}
-	return ""
+	return 0
 }
 
-type DeleteBikeRequest struct {
+type CreateBookingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BikeId    int32                  `protobuf:"varint,1,opt,name=bike_id,json=bikeId,proto3" json:"bike_id,omitempty"`
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
 }
 
-func (x *DeleteBikeRequest) Reset() {
-	*x = DeleteBikeRequest{}
-	mi := &file_rental_proto_msgTypes[6]
+func (x *CreateBookingRequest) Reset() {
+	*x = CreateBookingRequest{}
+	mi := &file_rental_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteBikeRequest) String() string {
+func (x *CreateBookingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteBikeRequest) ProtoMessage() {}
+func (*CreateBookingRequest) ProtoMessage() {}
 
-func (x *DeleteBikeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rental_proto_msgTypes[6]
+func (x *CreateBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -422,42 +1452,55 @@ func (x *DeleteBikeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteBikeRequest.ProtoReflect.Descriptor instead.
-func (*DeleteBikeRequest) Descriptor() ([]byte, []int) {
-	return file_rental_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use CreateBookingRequest.ProtoReflect.Descriptor instead.
+func (*CreateBookingRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *DeleteBikeRequest) GetId() int32 {
+func (x *CreateBookingRequest) GetBikeId() int32 {
 	if x != nil {
-		return x.Id
+		return x.BikeId
 	}
 	return 0
 }
 
-type ListBikesRequest struct {
+func (x *CreateBookingRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CreateBookingRequest) GetEndTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndTime
+	}
+	return nil
+}
+
+type CancelBookingRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Page     int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *ListBikesRequest) Reset() {
-	*x = ListBikesRequest{}
-	mi := &file_rental_proto_msgTypes[7]
+func (x *CancelBookingRequest) Reset() {
+	*x = CancelBookingRequest{}
+	mi := &file_rental_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListBikesRequest) String() string {
+func (x *CancelBookingRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBikesRequest) ProtoMessage() {}
+func (*CancelBookingRequest) ProtoMessage() {}
 
-func (x *ListBikesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rental_proto_msgTypes[7]
+func (x *CancelBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -468,48 +1511,41 @@ func (x *ListBikesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListBikesRequest.ProtoReflect.Descriptor instead.
-func (*ListBikesRequest) Descriptor() ([]byte, []int) {
-	return file_rental_proto_rawDescGZIP(), []int{7}
-}
-
-func (x *ListBikesRequest) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
+// Deprecated: Use CancelBookingRequest.ProtoReflect.Descriptor instead.
+func (*CancelBookingRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ListBikesRequest) GetPageSize() int32 {
+func (x *CancelBookingRequest) GetId() int32 {
 	if x != nil {
-		return x.PageSize
+		return x.Id
 	}
 	return 0
 }
 
-type ListBikesResponse struct {
+type ReserveBikeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Bikes []*Bike `protobuf:"bytes,1,rep,name=bikes,proto3" json:"bikes,omitempty"`
+	BikeId int32 `protobuf:"varint,1,opt,name=bike_id,json=bikeId,proto3" json:"bike_id,omitempty"`
 }
 
-func (x *ListBikesResponse) Reset() {
-	*x = ListBikesResponse{}
-	mi := &file_rental_proto_msgTypes[8]
+func (x *ReserveBikeRequest) Reset() {
+	*x = ReserveBikeRequest{}
+	mi := &file_rental_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListBikesResponse) String() string {
+func (x *ReserveBikeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBikesResponse) ProtoMessage() {}
+func (*ReserveBikeRequest) ProtoMessage() {}
 
-func (x *ListBikesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rental_proto_msgTypes[8]
+func (x *ReserveBikeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -520,41 +1556,41 @@ func (x *ListBikesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListBikesResponse.ProtoReflect.Descriptor instead.
-func (*ListBikesResponse) Descriptor() ([]byte, []int) {
-	return file_rental_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use ReserveBikeRequest.ProtoReflect.Descriptor instead.
+func (*ReserveBikeRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ListBikesResponse) GetBikes() []*Bike {
+func (x *ReserveBikeRequest) GetBikeId() int32 {
 	if x != nil {
-		return x.Bikes
+		return x.BikeId
 	}
-	return nil
+	return 0
 }
 
-type DeletedBikeResponse struct {
+type GetReservationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Messsage string `protobuf:"bytes,1,opt,name=messsage,proto3" json:"messsage,omitempty"`
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *DeletedBikeResponse) Reset() {
-	*x = DeletedBikeResponse{}
-	mi := &file_rental_proto_msgTypes[9]
+func (x *GetReservationRequest) Reset() {
+	*x = GetReservationRequest{}
+	mi := &file_rental_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeletedBikeResponse) String() string {
+func (x *GetReservationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeletedBikeResponse) ProtoMessage() {}
+func (*GetReservationRequest) ProtoMessage() {}
 
-func (x *DeletedBikeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rental_proto_msgTypes[9]
+func (x *GetReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -565,42 +1601,41 @@ func (x *DeletedBikeResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeletedBikeResponse.ProtoReflect.Descriptor instead.
-func (*DeletedBikeResponse) Descriptor() ([]byte, []int) {
-	return file_rental_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetReservationRequest.ProtoReflect.Descriptor instead.
+func (*GetReservationRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *DeletedBikeResponse) GetMesssage() string {
+func (x *GetReservationRequest) GetId() int32 {
 	if x != nil {
-		return x.Messsage
+		return x.Id
 	}
-	return ""
+	return 0
 }
 
-// Rental Operations
-type CreateRentalRequest struct {
+type CancelReservationRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BikeId int32 `protobuf:"varint,1,opt,name=bike_id,json=bikeId,proto3" json:"bike_id,omitempty"`
+	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *CreateRentalRequest) Reset() {
-	*x = CreateRentalRequest{}
-	mi := &file_rental_proto_msgTypes[10]
+func (x *CancelReservationRequest) Reset() {
+	*x = CancelReservationRequest{}
+	mi := &file_rental_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRentalRequest) String() string {
+func (x *CancelReservationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRentalRequest) ProtoMessage() {}
+func (*CancelReservationRequest) ProtoMessage() {}
 
-func (x *CreateRentalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rental_proto_msgTypes[10]
+func (x *CancelReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -611,41 +1646,44 @@ func (x *CreateRentalRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRentalRequest.ProtoReflect.Descriptor instead.
-func (*CreateRentalRequest) Descriptor() ([]byte, []int) {
-	return file_rental_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use CancelReservationRequest.ProtoReflect.Descriptor instead.
+func (*CancelReservationRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *CreateRentalRequest) GetBikeId() int32 {
+func (x *CancelReservationRequest) GetId() int32 {
 	if x != nil {
-		return x.BikeId
+		return x.Id
 	}
 	return 0
 }
 
-type GetRentalRequest struct {
+type QuoteRentalRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id int32 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BikeId  int32 `protobuf:"varint,1,opt,name=bike_id,json=bikeId,proto3" json:"bike_id,omitempty"`
+	Minutes int32 `protobuf:"varint,2,opt,name=minutes,proto3" json:"minutes,omitempty"`
+	// now when unset
+	StartTime *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
 }
 
-func (x *GetRentalRequest) Reset() {
-	*x = GetRentalRequest{}
-	mi := &file_rental_proto_msgTypes[11]
+func (x *QuoteRentalRequest) Reset() {
+	*x = QuoteRentalRequest{}
+	mi := &file_rental_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRentalRequest) String() string {
+func (x *QuoteRentalRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRentalRequest) ProtoMessage() {}
+func (*QuoteRentalRequest) ProtoMessage() {}
 
-func (x *GetRentalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rental_proto_msgTypes[11]
+func (x *QuoteRentalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -656,44 +1694,57 @@ func (x *GetRentalRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRentalRequest.ProtoReflect.Descriptor instead.
-func (*GetRentalRequest) Descriptor() ([]byte, []int) {
-	return file_rental_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use QuoteRentalRequest.ProtoReflect.Descriptor instead.
+func (*QuoteRentalRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *GetRentalRequest) GetId() int32 {
+func (x *QuoteRentalRequest) GetBikeId() int32 {
 	if x != nil {
-		return x.Id
+		return x.BikeId
 	}
 	return 0
 }
 
-type UpdateRentalRequest struct {
+func (x *QuoteRentalRequest) GetMinutes() int32 {
+	if x != nil {
+		return x.Minutes
+	}
+	return 0
+}
+
+func (x *QuoteRentalRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+type QuoteRentalReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id      int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	BikeId  int32                  `protobuf:"varint,3,opt,name=bike_id,json=bikeId,proto3" json:"bike_id,omitempty"`
-	EndTime *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	Status  string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	CostCents     int32 `protobuf:"varint,1,opt,name=cost_cents,json=costCents,proto3" json:"cost_cents,omitempty"`
+	TariffId      int32 `protobuf:"varint,2,opt,name=tariff_id,json=tariffId,proto3" json:"tariff_id,omitempty"`
+	TariffVersion int32 `protobuf:"varint,3,opt,name=tariff_version,json=tariffVersion,proto3" json:"tariff_version,omitempty"`
 }
 
-func (x *UpdateRentalRequest) Reset() {
-	*x = UpdateRentalRequest{}
-	mi := &file_rental_proto_msgTypes[12]
+func (x *QuoteRentalReply) Reset() {
+	*x = QuoteRentalReply{}
+	mi := &file_rental_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateRentalRequest) String() string {
+func (x *QuoteRentalReply) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateRentalRequest) ProtoMessage() {}
+func (*QuoteRentalReply) ProtoMessage() {}
 
-func (x *UpdateRentalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rental_proto_msgTypes[12]
+func (x *QuoteRentalReply) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -704,37 +1755,30 @@ func (x *UpdateRentalRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateRentalRequest.ProtoReflect.Descriptor instead.
-func (*UpdateRentalRequest) Descriptor() ([]byte, []int) {
-	return file_rental_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use QuoteRentalReply.ProtoReflect.Descriptor instead.
+func (*QuoteRentalReply) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *UpdateRentalRequest) GetId() int32 {
+func (x *QuoteRentalReply) GetCostCents() int32 {
 	if x != nil {
-		return x.Id
+		return x.CostCents
 	}
 	return 0
 }
 
-func (x *UpdateRentalRequest) GetBikeId() int32 {
+func (x *QuoteRentalReply) GetTariffId() int32 {
 	if x != nil {
-		return x.BikeId
+		return x.TariffId
 	}
 	return 0
 }
 
-func (x *UpdateRentalRequest) GetEndTime() *timestamppb.Timestamp {
-	if x != nil {
-		return x.EndTime
-	}
-	return nil
-}
-
-func (x *UpdateRentalRequest) GetStatus() string {
+func (x *QuoteRentalReply) GetTariffVersion() int32 {
 	if x != nil {
-		return x.Status
+		return x.TariffVersion
 	}
-	return ""
+	return 0
 }
 
 type DeleteRentalRequest struct {
@@ -747,7 +1791,7 @@ type DeleteRentalRequest struct {
 
 func (x *DeleteRentalRequest) Reset() {
 	*x = DeleteRentalRequest{}
-	mi := &file_rental_proto_msgTypes[13]
+	mi := &file_rental_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -759,7 +1803,7 @@ func (x *DeleteRentalRequest) String() string {
 func (*DeleteRentalRequest) ProtoMessage() {}
 
 func (x *DeleteRentalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rental_proto_msgTypes[13]
+	mi := &file_rental_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -772,7 +1816,7 @@ func (x *DeleteRentalRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteRentalRequest.ProtoReflect.Descriptor instead.
 func (*DeleteRentalRequest) Descriptor() ([]byte, []int) {
-	return file_rental_proto_rawDescGZIP(), []int{13}
+	return file_rental_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *DeleteRentalRequest) GetId() int32 {
@@ -793,7 +1837,7 @@ type ListRentalsRequest struct {
 
 func (x *ListRentalsRequest) Reset() {
 	*x = ListRentalsRequest{}
-	mi := &file_rental_proto_msgTypes[14]
+	mi := &file_rental_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -805,7 +1849,7 @@ func (x *ListRentalsRequest) String() string {
 func (*ListRentalsRequest) ProtoMessage() {}
 
 func (x *ListRentalsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_rental_proto_msgTypes[14]
+	mi := &file_rental_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -818,7 +1862,7 @@ func (x *ListRentalsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRentalsRequest.ProtoReflect.Descriptor instead.
 func (*ListRentalsRequest) Descriptor() ([]byte, []int) {
-	return file_rental_proto_rawDescGZIP(), []int{14}
+	return file_rental_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *ListRentalsRequest) GetPage() int32 {
@@ -835,6 +1879,84 @@ func (x *ListRentalsRequest) GetPageSize() int32 {
 	return 0
 }
 
+type ListMyRentalsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Page     int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// ONGOING or COMPLETED, all rentals when empty
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	StartedAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=started_after,json=startedAfter,proto3" json:"started_after,omitempty"`
+	StartedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_before,json=startedBefore,proto3" json:"started_before,omitempty"`
+}
+
+func (x *ListMyRentalsRequest) Reset() {
+	*x = ListMyRentalsRequest{}
+	mi := &file_rental_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMyRentalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMyRentalsRequest) ProtoMessage() {}
+
+func (x *ListMyRentalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rental_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMyRentalsRequest.ProtoReflect.Descriptor instead.
+func (*ListMyRentalsRequest) Descriptor() ([]byte, []int) {
+	return file_rental_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListMyRentalsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMyRentalsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListMyRentalsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListMyRentalsRequest) GetStartedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAfter
+	}
+	return nil
+}
+
+func (x *ListMyRentalsRequest) GetStartedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedBefore
+	}
+	return nil
+}
+
 type ListRentalsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -845,7 +1967,7 @@ type ListRentalsResponse struct {
 
 func (x *ListRentalsResponse) Reset() {
 	*x = ListRentalsResponse{}
-	mi := &file_rental_proto_msgTypes[15]
+	mi := &file_rental_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -857,7 +1979,7 @@ func (x *ListRentalsResponse) String() string {
 func (*ListRentalsResponse) ProtoMessage() {}
 
 func (x *ListRentalsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_rental_proto_msgTypes[15]
+	mi := &file_rental_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -870,7 +1992,7 @@ func (x *ListRentalsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRentalsResponse.ProtoReflect.Descriptor instead.
 func (*ListRentalsResponse) Descriptor() ([]byte, []int) {
-	return file_rental_proto_rawDescGZIP(), []int{15}
+	return file_rental_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ListRentalsResponse) GetRentals() []*Rental {
@@ -888,151 +2010,389 @@ var file_rental_proto_rawDesc = []byte{
 	0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f, 0x74, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xba, 0x01, 0x0a, 0x04, 0x42, 0x69,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe0, 0x02, 0x0a, 0x04, 0x42, 0x69,
 	0x6b, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02,
 	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xd4, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x6e, 0x74, 0x61,
-	0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69,
-	0x6b, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x69, 0x6b,
-	0x65, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x42, 0x69, 0x6b, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
-	0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35,
-	0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x64, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x06, 0x64, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0d, 0x62, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x79, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x05, 0x48, 0x00, 0x52, 0x0c, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x88, 0x01, 0x01, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79,
+	0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0x91, 0x03, 0x0a,
+	0x06, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x6b, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x62, 0x69, 0x6b, 0x65, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x63, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x73, 0x74, 0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x2a, 0x0a,
+	0x11, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x72,
+	0x69, 0x66, 0x66, 0x5f, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x74, 0x61,
+	0x72, 0x69, 0x66, 0x66, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0e, 0x73, 0x74, 0x61, 0x72, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x22, 0xb1, 0x02, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x6b,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x69, 0x6b, 0x65,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78,
+	0x70, 0x69, 0x72, 0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69,
+	0x72, 0x65, 0x73, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07,
+	0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x49, 0x64, 0x22, 0x90, 0x02, 0x0a, 0x07, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x6b,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x69, 0x6b, 0x65,
+	0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a,
+	0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x0a,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
 	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e,
-	0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x31, 0x0a,
-	0x15, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x22, 0x41, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x90, 0x01, 0x0a, 0x08, 0x54, 0x69, 0x6d, 0x65,
+	0x53, 0x6c, 0x6f, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65,
+	0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0xaf, 0x01, 0x0a, 0x10, 0x42,
+	0x69, 0x6b, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12,
+	0x17, 0x0a, 0x07, 0x62, 0x69, 0x6b, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x62, 0x69, 0x6b, 0x65, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x42, 0x69, 0x6b, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x62, 0x75, 0x73, 0x79,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e,
+	0x74, 0x61, 0x6c, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x04, 0x62, 0x75,
+	0x73, 0x79, 0x12, 0x28, 0x0a, 0x04, 0x66, 0x72, 0x65, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x53, 0x6c, 0x6f, 0x74, 0x52, 0x04, 0x66, 0x72, 0x65, 0x65, 0x22, 0x31, 0x0a, 0x15,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0x8c, 0x01, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x73,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x22, 0x20, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x51, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42,
-	0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f,
-	0x64, 0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
-	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x23, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65,
-	0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x43, 0x0a,
-	0x10, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69,
-	0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69,
-	0x7a, 0x65, 0x22, 0x3b, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x73, 0x52,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x2e, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x62, 0x69,
+	0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x42, 0x69, 0x6b, 0x65, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x17, 0x0a, 0x07, 0x64, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x64, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x20,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0xd8, 0x01, 0x0a, 0x11, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x2e, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x62,
+	0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x42, 0x69, 0x6b, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x64, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x06, 0x64, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x0d, 0x62, 0x61, 0x74,
+	0x74, 0x65, 0x72, 0x79, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x48, 0x00, 0x52, 0x0c, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x88, 0x01, 0x01, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x5f,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x4a, 0x04, 0x08, 0x03, 0x10, 0x04, 0x22, 0x23, 0x0a, 0x11, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x76, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x19, 0x0a,
+	0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x62, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x22, 0xa7, 0x01, 0x0a, 0x1a, 0x47, 0x65, 0x74,
+	0x42, 0x69, 0x6b, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x6b, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x69, 0x6b, 0x65, 0x49, 0x64,
+	0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65,
+	0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x22, 0x5a, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x73, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x62, 0x69, 0x6b, 0x65, 0x73,
 	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e,
-	0x74, 0x61, 0x6c, 0x2e, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x05, 0x62, 0x69, 0x6b, 0x65, 0x73, 0x22,
-	0x31, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x22, 0x2e, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74,
-	0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x6b,
-	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x69, 0x6b, 0x65,
-	0x49, 0x64, 0x22, 0x22, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x8d, 0x01, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17,
-	0x0a, 0x07, 0x62, 0x69, 0x6b, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x06, 0x62, 0x69, 0x6b, 0x65, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x74,
-	0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x25, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x74, 0x61, 0x6c, 0x2e, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x05, 0x62, 0x69, 0x6b, 0x65, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x31,
+	0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x22, 0x2e, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x6b, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x69, 0x6b, 0x65, 0x49,
+	0x64, 0x22, 0x22, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x91, 0x01, 0x0a, 0x13, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
 	0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x45, 0x0a,
-	0x12, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f,
-	0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65,
-	0x53, 0x69, 0x7a, 0x65, 0x22, 0x43, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x74,
-	0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x72,
-	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a,
+	0x07, 0x62, 0x69, 0x6b, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02,
+	0x18, 0x01, 0x52, 0x06, 0x62, 0x69, 0x6b, 0x65, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e,
+	0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x81, 0x01, 0x0a, 0x10, 0x45, 0x6e,
+	0x64, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x11, 0x72,
+	0x65, 0x74, 0x75, 0x72, 0x6e, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x72, 0x65, 0x74, 0x75, 0x72,
+	0x6e, 0x5f, 0x64, 0x6f, 0x63, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x72, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x44, 0x6f, 0x63, 0x6b, 0x49, 0x64, 0x22, 0xa1, 0x01,
+	0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x6b, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x69, 0x6b, 0x65, 0x49, 0x64, 0x12,
+	0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x65, 0x6e,
+	0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d,
+	0x65, 0x22, 0x26, 0x0a, 0x14, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x6f, 0x6f, 0x6b, 0x69,
+	0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2d, 0x0a, 0x12, 0x52, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x17, 0x0a, 0x07, 0x62, 0x69, 0x6b, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x62, 0x69, 0x6b, 0x65, 0x49, 0x64, 0x22, 0x27, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x2a, 0x0a, 0x18, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64, 0x22, 0x82, 0x01,
+	0x0a, 0x12, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x62, 0x69, 0x6b, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x62, 0x69, 0x6b, 0x65, 0x49, 0x64, 0x12, 0x18, 0x0a,
+	0x07, 0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x6d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x22, 0x75, 0x0a, 0x10, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x63,
+	0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x63, 0x6f, 0x73, 0x74,
+	0x43, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x61, 0x72, 0x69, 0x66, 0x66, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x74, 0x61, 0x72, 0x69, 0x66, 0x66,
+	0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x61, 0x72, 0x69, 0x66, 0x66, 0x5f, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x74, 0x61, 0x72, 0x69,
+	0x66, 0x66, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x25, 0x0a, 0x13, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x45, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70,
+	0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xe3, 0x01, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74,
+	0x4d, 0x79, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3f, 0x0a, 0x0d, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x66, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x66, 0x74, 0x65, 0x72, 0x12, 0x41, 0x0a, 0x0e, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x42, 0x65, 0x66, 0x6f, 0x72, 0x65, 0x22, 0x43, 0x0a,
+	0x13, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x07, 0x72, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x73, 0x2a, 0x7a, 0x0a, 0x0a, 0x42, 0x69, 0x6b, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x1b, 0x0a, 0x17, 0x42, 0x49, 0x4b, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0d, 0x0a,
+	0x09, 0x41, 0x56, 0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06,
+	0x52, 0x45, 0x4e, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x53, 0x45,
+	0x52, 0x56, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x4d, 0x41, 0x49, 0x4e, 0x54, 0x45,
+	0x4e, 0x41, 0x4e, 0x43, 0x45, 0x10, 0x04, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x54, 0x49, 0x52,
+	0x45, 0x44, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x4c, 0x4f, 0x53, 0x54, 0x10, 0x06, 0x32, 0xd8,
+	0x04, 0x0a, 0x0b, 0x42, 0x69, 0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x53,
+	0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x12, 0x1d, 0x2e, 0x62,
+	0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x42, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x62, 0x69,
+	0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x42, 0x69, 0x6b, 0x65, 0x22, 0x14, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x3a, 0x01, 0x2a, 0x22, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x69,
+	0x6b, 0x65, 0x73, 0x12, 0x4f, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x12, 0x1a,
+	0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x42,
+	0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x62, 0x69, 0x6b,
+	0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x42, 0x69, 0x6b, 0x65, 0x22, 0x16, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x10, 0x12, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x69, 0x6b, 0x65, 0x73, 0x2f,
+	0x7b, 0x69, 0x64, 0x7d, 0x12, 0x58, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x69,
+	0x6b, 0x65, 0x12, 0x1d, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x10, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x42,
+	0x69, 0x6b, 0x65, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x3a, 0x01, 0x2a, 0x1a, 0x0e,
+	0x2f, 0x76, 0x31, 0x2f, 0x62, 0x69, 0x6b, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x64,
+	0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x12, 0x1d, 0x2e, 0x62,
+	0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x42, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x62, 0x69,
+	0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x42, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x10, 0x2a, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x69, 0x6b, 0x65, 0x73, 0x2f,
+	0x7b, 0x69, 0x64, 0x7d, 0x12, 0x5b, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x69, 0x6b, 0x65,
+	0x73, 0x12, 0x1c, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x42, 0x69, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x11,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0b, 0x12, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x69, 0x6b, 0x65,
+	0x73, 0x12, 0x85, 0x01, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x41, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x26, 0x2e, 0x62, 0x69, 0x6b, 0x65,
+	0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x41, 0x76,
+	0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1c, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x42,
+	0x69, 0x6b, 0x65, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22,
+	0x28, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x22, 0x12, 0x20, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x69, 0x6b,
+	0x65, 0x73, 0x2f, 0x7b, 0x62, 0x69, 0x6b, 0x65, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x61, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x32, 0xe0, 0x0a, 0x0a, 0x0d, 0x52, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5b, 0x0a, 0x0c, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x1f, 0x2e, 0x62, 0x69,
+	0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x62,
 	0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c,
-	0x52, 0x07, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x32, 0xd0, 0x03, 0x0a, 0x0b, 0x42, 0x69,
-	0x6b, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x53, 0x0a, 0x0a, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x12, 0x1d, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65,
-	0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e,
-	0x74, 0x61, 0x6c, 0x2e, 0x42, 0x69, 0x6b, 0x65, 0x22, 0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e,
-	0x3a, 0x01, 0x2a, 0x22, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x69, 0x6b, 0x65, 0x73, 0x12, 0x4f,
-	0x0a, 0x07, 0x47, 0x65, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x12, 0x1a, 0x2e, 0x62, 0x69, 0x6b, 0x65,
-	0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74,
-	0x61, 0x6c, 0x2e, 0x42, 0x69, 0x6b, 0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x12,
-	0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x69, 0x6b, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12,
-	0x58, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x12, 0x1d, 0x2e,
-	0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x62,
-	0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x42, 0x69, 0x6b, 0x65, 0x22, 0x19,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x3a, 0x01, 0x2a, 0x1a, 0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x62,
-	0x69, 0x6b, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x64, 0x0a, 0x0a, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x12, 0x1d, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65,
-	0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e,
-	0x74, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x42, 0x69, 0x6b, 0x65, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x2a,
-	0x0e, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x69, 0x6b, 0x65, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12,
-	0x5b, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x69, 0x6b, 0x65, 0x73, 0x12, 0x1c, 0x2e, 0x62,
-	0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x69,
-	0x6b, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x62, 0x69, 0x6b,
-	0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x69, 0x6b, 0x65,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x11, 0x82, 0xd3, 0xe4, 0x93, 0x02,
-	0x0b, 0x12, 0x09, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x69, 0x6b, 0x65, 0x73, 0x32, 0xfa, 0x03, 0x0a,
-	0x0d, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5b,
-	0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x1f,
-	0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x3a, 0x01, 0x2a, 0x22, 0x0b, 0x2f, 0x76, 0x31,
+	0x2f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x12, 0x57, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x52,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x1c, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c,
+	0x2e, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12,
+	0x10, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x2f, 0x7b, 0x69, 0x64,
+	0x7d, 0x12, 0x60, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x12, 0x1f, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e,
+	0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x3a, 0x01,
+	0x2a, 0x1a, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x2f, 0x7b,
+	0x69, 0x64, 0x7d, 0x12, 0x5f, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f,
+	0x6b, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e,
+	0x74, 0x61, 0x6c, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x22, 0x17, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x11, 0x3a, 0x01, 0x2a, 0x22, 0x0c, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x6f, 0x6f, 0x6b,
+	0x69, 0x6e, 0x67, 0x73, 0x12, 0x6b, 0x0a, 0x0d, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x6f,
+	0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x12, 0x20, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74,
+	0x61, 0x6c, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x69, 0x6e, 0x67, 0x22, 0x23, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x1d, 0x3a, 0x01, 0x2a, 0x22, 0x18, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x6f, 0x6f,
+	0x6b, 0x69, 0x6e, 0x67, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x12, 0x63, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x42, 0x69, 0x6b, 0x65,
+	0x12, 0x1e, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x42, 0x69, 0x6b, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x17, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x15, 0x3a, 0x01, 0x2a, 0x22, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x6b, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x62, 0x69,
+	0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x1d, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x17, 0x12, 0x15, 0x2f, 0x76,
+	0x31, 0x2f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b,
+	0x69, 0x64, 0x7d, 0x12, 0x7b, 0x0a, 0x11, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x73,
+	0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x73, 0x65,
+	0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x27, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x21, 0x3a,
+	0x01, 0x2a, 0x22, 0x1c, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x2f, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x12, 0x6e, 0x0a, 0x0b, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12,
+	0x1e, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x51, 0x75, 0x6f,
 	0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x12, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x6e,
-	0x74, 0x61, 0x6c, 0x22, 0x16, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x10, 0x3a, 0x01, 0x2a, 0x22, 0x0b,
-	0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x12, 0x57, 0x0a, 0x09, 0x47,
-	0x65, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x1c, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72,
-	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e,
-	0x74, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x12, 0x12, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x2f,
-	0x7b, 0x69, 0x64, 0x7d, 0x12, 0x60, 0x0a, 0x0c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x6e, 0x74, 0x61, 0x6c, 0x12, 0x1f, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61,
-	0x6c, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74,
-	0x61, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02,
-	0x15, 0x3a, 0x01, 0x2a, 0x1a, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c,
-	0x73, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x6c, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x1f, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e,
-	0x74, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65,
-	0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x52, 0x65, 0x6e, 0x74,
-	0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93,
-	0x02, 0x12, 0x2a, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x2f,
-	0x7b, 0x69, 0x64, 0x7d, 0x12, 0x63, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x74,
-	0x61, 0x6c, 0x73, 0x12, 0x1e, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c,
-	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x12, 0x0b, 0x2f, 0x76,
-	0x31, 0x2f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x42, 0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x79, 0x6f, 0x75, 0x72, 0x75, 0x73, 0x65, 0x72,
-	0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x62, 0x69, 0x6b, 0x65, 0x2d, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c,
-	0x2d, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e,
-	0x74, 0x61, 0x6c, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x1c, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x51, 0x75, 0x6f,
+	0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x21, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x1b, 0x12, 0x19, 0x2f, 0x76, 0x31, 0x2f, 0x62, 0x69, 0x6b, 0x65, 0x73,
+	0x2f, 0x7b, 0x62, 0x69, 0x6b, 0x65, 0x5f, 0x69, 0x64, 0x7d, 0x2f, 0x71, 0x75, 0x6f, 0x74, 0x65,
+	0x12, 0x65, 0x0a, 0x09, 0x45, 0x6e, 0x64, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x1c, 0x2e,
+	0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x45, 0x6e, 0x64, 0x52, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x62, 0x69,
+	0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x22,
+	0x26, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x20, 0x3a, 0x01, 0x2a, 0x22, 0x1b, 0x2f, 0x76, 0x31, 0x2f,
+	0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x2f, 0x7b, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x5f,
+	0x69, 0x64, 0x7d, 0x2f, 0x65, 0x6e, 0x64, 0x12, 0x6c, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x1f, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65,
+	0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x52, 0x65, 0x6e,
+	0x74, 0x61, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4,
+	0x93, 0x02, 0x12, 0x2a, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73,
+	0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x63, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6e,
+	0x74, 0x61, 0x6c, 0x73, 0x12, 0x1e, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61,
+	0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x13, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0d, 0x12, 0x0b, 0x2f,
+	0x76, 0x31, 0x2f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x12, 0x70, 0x0a, 0x0d, 0x4c, 0x69,
+	0x73, 0x74, 0x4d, 0x79, 0x52, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x12, 0x20, 0x2e, 0x62, 0x69,
+	0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x79, 0x52,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x62, 0x69, 0x6b, 0x65, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1c,
+	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x16, 0x12, 0x14, 0x2f, 0x76, 0x31, 0x2f, 0x75, 0x73, 0x65, 0x72,
+	0x73, 0x2f, 0x6d, 0x65, 0x2f, 0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x73, 0x42, 0x3a, 0x5a, 0x38,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x79, 0x6f, 0x75, 0x72, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x2f, 0x62, 0x69, 0x6b, 0x65, 0x2d, 0x72, 0x65, 0x6e,
+	0x74, 0x61, 0x6c, 0x2d, 0x62, 0x61, 0x63, 0x6b, 0x65, 0x6e, 0x64, 0x2f, 0x62, 0x69, 0x6b, 0x65,
+	0x72, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1047,59 +2407,114 @@ func file_rental_proto_rawDescGZIP() []byte {
 	return file_rental_proto_rawDescData
 }
 
-var file_rental_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_rental_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_rental_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_rental_proto_goTypes = []any{
-	(*Bike)(nil),                  // 0: bikerental.Bike
-	(*Rental)(nil),                // 1: bikerental.Rental
-	(*DeletedRentalResponse)(nil), // 2: bikerental.DeletedRentalResponse
-	(*CreateBikeRequest)(nil),     // 3: bikerental.CreateBikeRequest
-	(*GetBikeRequest)(nil),        // 4: bikerental.GetBikeRequest
-	(*UpdateBikeRequest)(nil),     // 5: bikerental.UpdateBikeRequest
-	(*DeleteBikeRequest)(nil),     // 6: bikerental.DeleteBikeRequest
-	(*ListBikesRequest)(nil),      // 7: bikerental.ListBikesRequest
-	(*ListBikesResponse)(nil),     // 8: bikerental.ListBikesResponse
-	(*DeletedBikeResponse)(nil),   // 9: bikerental.DeletedBikeResponse
-	(*CreateRentalRequest)(nil),   // 10: bikerental.CreateRentalRequest
-	(*GetRentalRequest)(nil),      // 11: bikerental.GetRentalRequest
-	(*UpdateRentalRequest)(nil),   // 12: bikerental.UpdateRentalRequest
-	(*DeleteRentalRequest)(nil),   // 13: bikerental.DeleteRentalRequest
-	(*ListRentalsRequest)(nil),    // 14: bikerental.ListRentalsRequest
-	(*ListRentalsResponse)(nil),   // 15: bikerental.ListRentalsResponse
-	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(BikeStatus)(0),                    // 0: bikerental.BikeStatus
+	(*Bike)(nil),                       // 1: bikerental.Bike
+	(*Rental)(nil),                     // 2: bikerental.Rental
+	(*Reservation)(nil),                // 3: bikerental.Reservation
+	(*Booking)(nil),                    // 4: bikerental.Booking
+	(*TimeSlot)(nil),                   // 5: bikerental.TimeSlot
+	(*BikeAvailability)(nil),           // 6: bikerental.BikeAvailability
+	(*DeletedRentalResponse)(nil),      // 7: bikerental.DeletedRentalResponse
+	(*CreateBikeRequest)(nil),          // 8: bikerental.CreateBikeRequest
+	(*GetBikeRequest)(nil),             // 9: bikerental.GetBikeRequest
+	(*UpdateBikeRequest)(nil),          // 10: bikerental.UpdateBikeRequest
+	(*DeleteBikeRequest)(nil),          // 11: bikerental.DeleteBikeRequest
+	(*ListBikesRequest)(nil),           // 12: bikerental.ListBikesRequest
+	(*GetBikeAvailabilityRequest)(nil), // 13: bikerental.GetBikeAvailabilityRequest
+	(*ListBikesResponse)(nil),          // 14: bikerental.ListBikesResponse
+	(*DeletedBikeResponse)(nil),        // 15: bikerental.DeletedBikeResponse
+	(*CreateRentalRequest)(nil),        // 16: bikerental.CreateRentalRequest
+	(*GetRentalRequest)(nil),           // 17: bikerental.GetRentalRequest
+	(*UpdateRentalRequest)(nil),        // 18: bikerental.UpdateRentalRequest
+	(*EndRentalRequest)(nil),           // 19: bikerental.EndRentalRequest
+	(*CreateBookingRequest)(nil),       // 20: bikerental.CreateBookingRequest
+	(*CancelBookingRequest)(nil),       // 21: bikerental.CancelBookingRequest
+	(*ReserveBikeRequest)(nil),         // 22: bikerental.ReserveBikeRequest
+	(*GetReservationRequest)(nil),      // 23: bikerental.GetReservationRequest
+	(*CancelReservationRequest)(nil),   // 24: bikerental.CancelReservationRequest
+	(*QuoteRentalRequest)(nil),         // 25: bikerental.QuoteRentalRequest
+	(*QuoteRentalReply)(nil),           // 26: bikerental.QuoteRentalReply
+	(*DeleteRentalRequest)(nil),        // 27: bikerental.DeleteRentalRequest
+	(*ListRentalsRequest)(nil),         // 28: bikerental.ListRentalsRequest
+	(*ListMyRentalsRequest)(nil),       // 29: bikerental.ListMyRentalsRequest
+	(*ListRentalsResponse)(nil),        // 30: bikerental.ListRentalsResponse
+	(*timestamppb.Timestamp)(nil),      // 31: google.protobuf.Timestamp
 }
 var file_rental_proto_depIdxs = []int32{
-	16, // 0: bikerental.Bike.created_at:type_name -> google.protobuf.Timestamp
-	16, // 1: bikerental.Bike.updated_at:type_name -> google.protobuf.Timestamp
-	16, // 2: bikerental.Rental.start_time:type_name -> google.protobuf.Timestamp
-	16, // 3: bikerental.Rental.end_time:type_name -> google.protobuf.Timestamp
-	0,  // 4: bikerental.ListBikesResponse.bikes:type_name -> bikerental.Bike
-	16, // 5: bikerental.UpdateRentalRequest.end_time:type_name -> google.protobuf.Timestamp
-	1,  // 6: bikerental.ListRentalsResponse.rentals:type_name -> bikerental.Rental
-	3,  // 7: bikerental.BikeService.CreateBike:input_type -> bikerental.CreateBikeRequest
-	4,  // 8: bikerental.BikeService.GetBike:input_type -> bikerental.GetBikeRequest
-	5,  // 9: bikerental.BikeService.UpdateBike:input_type -> bikerental.UpdateBikeRequest
-	6,  // 10: bikerental.BikeService.DeleteBike:input_type -> bikerental.DeleteBikeRequest
-	7,  // 11: bikerental.BikeService.ListBikes:input_type -> bikerental.ListBikesRequest
-	10, // 12: bikerental.RentalService.CreateRental:input_type -> bikerental.CreateRentalRequest
-	11, // 13: bikerental.RentalService.GetRental:input_type -> bikerental.GetRentalRequest
-	12, // 14: bikerental.RentalService.UpdateRental:input_type -> bikerental.UpdateRentalRequest
-	13, // 15: bikerental.RentalService.DeleteRental:input_type -> bikerental.DeleteRentalRequest
-	14, // 16: bikerental.RentalService.ListRentals:input_type -> bikerental.ListRentalsRequest
-	0,  // 17: bikerental.BikeService.CreateBike:output_type -> bikerental.Bike
-	0,  // 18: bikerental.BikeService.GetBike:output_type -> bikerental.Bike
-	0,  // 19: bikerental.BikeService.UpdateBike:output_type -> bikerental.Bike
-	9,  // 20: bikerental.BikeService.DeleteBike:output_type -> bikerental.DeletedBikeResponse
-	8,  // 21: bikerental.BikeService.ListBikes:output_type -> bikerental.ListBikesResponse
-	1,  // 22: bikerental.RentalService.CreateRental:output_type -> bikerental.Rental
-	1,  // 23: bikerental.RentalService.GetRental:output_type -> bikerental.Rental
-	1,  // 24: bikerental.RentalService.UpdateRental:output_type -> bikerental.Rental
-	2,  // 25: bikerental.RentalService.DeleteRental:output_type -> bikerental.DeletedRentalResponse
-	15, // 26: bikerental.RentalService.ListRentals:output_type -> bikerental.ListRentalsResponse
-	17, // [17:27] is the sub-list for method output_type
-	7,  // [7:17] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	0,  // 0: bikerental.Bike.status:type_name -> bikerental.BikeStatus
+	31, // 1: bikerental.Bike.created_at:type_name -> google.protobuf.Timestamp
+	31, // 2: bikerental.Bike.updated_at:type_name -> google.protobuf.Timestamp
+	31, // 3: bikerental.Rental.start_time:type_name -> google.protobuf.Timestamp
+	31, // 4: bikerental.Rental.end_time:type_name -> google.protobuf.Timestamp
+	31, // 5: bikerental.Reservation.expires_at:type_name -> google.protobuf.Timestamp
+	31, // 6: bikerental.Reservation.created_at:type_name -> google.protobuf.Timestamp
+	31, // 7: bikerental.Reservation.ended_at:type_name -> google.protobuf.Timestamp
+	31, // 8: bikerental.Booking.start_time:type_name -> google.protobuf.Timestamp
+	31, // 9: bikerental.Booking.end_time:type_name -> google.protobuf.Timestamp
+	31, // 10: bikerental.Booking.created_at:type_name -> google.protobuf.Timestamp
+	31, // 11: bikerental.TimeSlot.start_time:type_name -> google.protobuf.Timestamp
+	31, // 12: bikerental.TimeSlot.end_time:type_name -> google.protobuf.Timestamp
+	0,  // 13: bikerental.BikeAvailability.status:type_name -> bikerental.BikeStatus
+	5,  // 14: bikerental.BikeAvailability.busy:type_name -> bikerental.TimeSlot
+	5,  // 15: bikerental.BikeAvailability.free:type_name -> bikerental.TimeSlot
+	0,  // 16: bikerental.CreateBikeRequest.status:type_name -> bikerental.BikeStatus
+	0,  // 17: bikerental.UpdateBikeRequest.status:type_name -> bikerental.BikeStatus
+	31, // 18: bikerental.GetBikeAvailabilityRequest.start_time:type_name -> google.protobuf.Timestamp
+	31, // 19: bikerental.GetBikeAvailabilityRequest.end_time:type_name -> google.protobuf.Timestamp
+	1,  // 20: bikerental.ListBikesResponse.bikes:type_name -> bikerental.Bike
+	31, // 21: bikerental.UpdateRentalRequest.end_time:type_name -> google.protobuf.Timestamp
+	31, // 22: bikerental.CreateBookingRequest.start_time:type_name -> google.protobuf.Timestamp
+	31, // 23: bikerental.CreateBookingRequest.end_time:type_name -> google.protobuf.Timestamp
+	31, // 24: bikerental.QuoteRentalRequest.start_time:type_name -> google.protobuf.Timestamp
+	31, // 25: bikerental.ListMyRentalsRequest.started_after:type_name -> google.protobuf.Timestamp
+	31, // 26: bikerental.ListMyRentalsRequest.started_before:type_name -> google.protobuf.Timestamp
+	2,  // 27: bikerental.ListRentalsResponse.rentals:type_name -> bikerental.Rental
+	8,  // 28: bikerental.BikeService.CreateBike:input_type -> bikerental.CreateBikeRequest
+	9,  // 29: bikerental.BikeService.GetBike:input_type -> bikerental.GetBikeRequest
+	10, // 30: bikerental.BikeService.UpdateBike:input_type -> bikerental.UpdateBikeRequest
+	11, // 31: bikerental.BikeService.DeleteBike:input_type -> bikerental.DeleteBikeRequest
+	12, // 32: bikerental.BikeService.ListBikes:input_type -> bikerental.ListBikesRequest
+	13, // 33: bikerental.BikeService.GetBikeAvailability:input_type -> bikerental.GetBikeAvailabilityRequest
+	16, // 34: bikerental.RentalService.CreateRental:input_type -> bikerental.CreateRentalRequest
+	17, // 35: bikerental.RentalService.GetRental:input_type -> bikerental.GetRentalRequest
+	18, // 36: bikerental.RentalService.UpdateRental:input_type -> bikerental.UpdateRentalRequest
+	20, // 37: bikerental.RentalService.CreateBooking:input_type -> bikerental.CreateBookingRequest
+	21, // 38: bikerental.RentalService.CancelBooking:input_type -> bikerental.CancelBookingRequest
+	22, // 39: bikerental.RentalService.ReserveBike:input_type -> bikerental.ReserveBikeRequest
+	23, // 40: bikerental.RentalService.GetReservation:input_type -> bikerental.GetReservationRequest
+	24, // 41: bikerental.RentalService.CancelReservation:input_type -> bikerental.CancelReservationRequest
+	25, // 42: bikerental.RentalService.QuoteRental:input_type -> bikerental.QuoteRentalRequest
+	19, // 43: bikerental.RentalService.EndRental:input_type -> bikerental.EndRentalRequest
+	27, // 44: bikerental.RentalService.DeleteRental:input_type -> bikerental.DeleteRentalRequest
+	28, // 45: bikerental.RentalService.ListRentals:input_type -> bikerental.ListRentalsRequest
+	29, // 46: bikerental.RentalService.ListMyRentals:input_type -> bikerental.ListMyRentalsRequest
+	1,  // 47: bikerental.BikeService.CreateBike:output_type -> bikerental.Bike
+	1,  // 48: bikerental.BikeService.GetBike:output_type -> bikerental.Bike
+	1,  // 49: bikerental.BikeService.UpdateBike:output_type -> bikerental.Bike
+	15, // 50: bikerental.BikeService.DeleteBike:output_type -> bikerental.DeletedBikeResponse
+	14, // 51: bikerental.BikeService.ListBikes:output_type -> bikerental.ListBikesResponse
+	6,  // 52: bikerental.BikeService.GetBikeAvailability:output_type -> bikerental.BikeAvailability
+	2,  // 53: bikerental.RentalService.CreateRental:output_type -> bikerental.Rental
+	2,  // 54: bikerental.RentalService.GetRental:output_type -> bikerental.Rental
+	2,  // 55: bikerental.RentalService.UpdateRental:output_type -> bikerental.Rental
+	4,  // 56: bikerental.RentalService.CreateBooking:output_type -> bikerental.Booking
+	4,  // 57: bikerental.RentalService.CancelBooking:output_type -> bikerental.Booking
+	3,  // 58: bikerental.RentalService.ReserveBike:output_type -> bikerental.Reservation
+	3,  // 59: bikerental.RentalService.GetReservation:output_type -> bikerental.Reservation
+	3,  // 60: bikerental.RentalService.CancelReservation:output_type -> bikerental.Reservation
+	26, // 61: bikerental.RentalService.QuoteRental:output_type -> bikerental.QuoteRentalReply
+	2,  // 62: bikerental.RentalService.EndRental:output_type -> bikerental.Rental
+	7,  // 63: bikerental.RentalService.DeleteRental:output_type -> bikerental.DeletedRentalResponse
+	30, // 64: bikerental.RentalService.ListRentals:output_type -> bikerental.ListRentalsResponse
+	30, // 65: bikerental.RentalService.ListMyRentals:output_type -> bikerental.ListRentalsResponse
+	47, // [47:66] is the sub-list for method output_type
+	28, // [28:47] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_rental_proto_init() }
@@ -1107,18 +2522,21 @@ func file_rental_proto_init() {
 	if File_rental_proto != nil {
 		return
 	}
+	file_rental_proto_msgTypes[0].OneofWrappers = []any{}
+	file_rental_proto_msgTypes[9].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_rental_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   16,
+			NumEnums:      1,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
 		GoTypes:           file_rental_proto_goTypes,
 		DependencyIndexes: file_rental_proto_depIdxs,
+		EnumInfos:         file_rental_proto_enumTypes,
 		MessageInfos:      file_rental_proto_msgTypes,
 	}.Build()
 	File_rental_proto = out.File
@@ -19,11 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	BikeService_CreateBike_FullMethodName = "/bikerental.BikeService/CreateBike"
-	BikeService_GetBike_FullMethodName    = "/bikerental.BikeService/GetBike"
-	BikeService_UpdateBike_FullMethodName = "/bikerental.BikeService/UpdateBike"
-	BikeService_DeleteBike_FullMethodName = "/bikerental.BikeService/DeleteBike"
-	BikeService_ListBikes_FullMethodName  = "/bikerental.BikeService/ListBikes"
+	BikeService_CreateBike_FullMethodName          = "/bikerental.BikeService/CreateBike"
+	BikeService_GetBike_FullMethodName             = "/bikerental.BikeService/GetBike"
+	BikeService_UpdateBike_FullMethodName          = "/bikerental.BikeService/UpdateBike"
+	BikeService_DeleteBike_FullMethodName          = "/bikerental.BikeService/DeleteBike"
+	BikeService_ListBikes_FullMethodName           = "/bikerental.BikeService/ListBikes"
+	BikeService_GetBikeAvailability_FullMethodName = "/bikerental.BikeService/GetBikeAvailability"
 )
 
 // BikeServiceClient is the client API for BikeService service.
@@ -40,6 +41,8 @@ type BikeServiceClient interface {
 	DeleteBike(ctx context.Context, in *DeleteBikeRequest, opts ...grpc.CallOption) (*DeletedBikeResponse, error)
 	// List bikes with pagination
 	ListBikes(ctx context.Context, in *ListBikesRequest, opts ...grpc.CallOption) (*ListBikesResponse, error)
+	// Show when a bike is booked or free in a time range
+	GetBikeAvailability(ctx context.Context, in *GetBikeAvailabilityRequest, opts ...grpc.CallOption) (*BikeAvailability, error)
 }
 
 type bikeServiceClient struct {
@@ -100,6 +103,16 @@ func (c *bikeServiceClient) ListBikes(ctx context.Context, in *ListBikesRequest,
 	return out, nil
 }
 
+func (c *bikeServiceClient) GetBikeAvailability(ctx context.Context, in *GetBikeAvailabilityRequest, opts ...grpc.CallOption) (*BikeAvailability, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BikeAvailability)
+	err := c.cc.Invoke(ctx, BikeService_GetBikeAvailability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BikeServiceServer is the server API for BikeService service.
 // All implementations must embed UnimplementedBikeServiceServer
 // for forward compatibility.
@@ -114,6 +127,8 @@ type BikeServiceServer interface {
 	DeleteBike(context.Context, *DeleteBikeRequest) (*DeletedBikeResponse, error)
 	// List bikes with pagination
 	ListBikes(context.Context, *ListBikesRequest) (*ListBikesResponse, error)
+	// Show when a bike is booked or free in a time range
+	GetBikeAvailability(context.Context, *GetBikeAvailabilityRequest) (*BikeAvailability, error)
 	mustEmbedUnimplementedBikeServiceServer()
 }
 
@@ -139,6 +154,9 @@ func (UnimplementedBikeServiceServer) DeleteBike(context.Context, *DeleteBikeReq
 func (UnimplementedBikeServiceServer) ListBikes(context.Context, *ListBikesRequest) (*ListBikesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListBikes not implemented")
 }
+func (UnimplementedBikeServiceServer) GetBikeAvailability(context.Context, *GetBikeAvailabilityRequest) (*BikeAvailability, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBikeAvailability not implemented")
+}
 func (UnimplementedBikeServiceServer) mustEmbedUnimplementedBikeServiceServer() {}
 func (UnimplementedBikeServiceServer) testEmbeddedByValue()                     {}
 
@@ -250,6 +268,24 @@ func _BikeService_ListBikes_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BikeService_GetBikeAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBikeAvailabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BikeServiceServer).GetBikeAvailability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BikeService_GetBikeAvailability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BikeServiceServer).GetBikeAvailability(ctx, req.(*GetBikeAvailabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BikeService_ServiceDesc is the grpc.ServiceDesc for BikeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -277,17 +313,29 @@ var BikeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListBikes",
 			Handler:    _BikeService_ListBikes_Handler,
 		},
+		{
+			MethodName: "GetBikeAvailability",
+			Handler:    _BikeService_GetBikeAvailability_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rental.proto",
 }
 
 const (
-	RentalService_CreateRental_FullMethodName = "/bikerental.RentalService/CreateRental"
-	RentalService_GetRental_FullMethodName    = "/bikerental.RentalService/GetRental"
-	RentalService_UpdateRental_FullMethodName = "/bikerental.RentalService/UpdateRental"
-	RentalService_DeleteRental_FullMethodName = "/bikerental.RentalService/DeleteRental"
-	RentalService_ListRentals_FullMethodName  = "/bikerental.RentalService/ListRentals"
+	RentalService_CreateRental_FullMethodName      = "/bikerental.RentalService/CreateRental"
+	RentalService_GetRental_FullMethodName         = "/bikerental.RentalService/GetRental"
+	RentalService_UpdateRental_FullMethodName      = "/bikerental.RentalService/UpdateRental"
+	RentalService_CreateBooking_FullMethodName     = "/bikerental.RentalService/CreateBooking"
+	RentalService_CancelBooking_FullMethodName     = "/bikerental.RentalService/CancelBooking"
+	RentalService_ReserveBike_FullMethodName       = "/bikerental.RentalService/ReserveBike"
+	RentalService_GetReservation_FullMethodName    = "/bikerental.RentalService/GetReservation"
+	RentalService_CancelReservation_FullMethodName = "/bikerental.RentalService/CancelReservation"
+	RentalService_QuoteRental_FullMethodName       = "/bikerental.RentalService/QuoteRental"
+	RentalService_EndRental_FullMethodName         = "/bikerental.RentalService/EndRental"
+	RentalService_DeleteRental_FullMethodName      = "/bikerental.RentalService/DeleteRental"
+	RentalService_ListRentals_FullMethodName       = "/bikerental.RentalService/ListRentals"
+	RentalService_ListMyRentals_FullMethodName     = "/bikerental.RentalService/ListMyRentals"
 )
 
 // RentalServiceClient is the client API for RentalService service.
@@ -300,10 +348,26 @@ type RentalServiceClient interface {
 	GetRental(ctx context.Context, in *GetRentalRequest, opts ...grpc.CallOption) (*Rental, error)
 	// Update a rental
 	UpdateRental(ctx context.Context, in *UpdateRentalRequest, opts ...grpc.CallOption) (*Rental, error)
+	// Book a bike for a future time range
+	CreateBooking(ctx context.Context, in *CreateBookingRequest, opts ...grpc.CallOption) (*Booking, error)
+	// Cancel a booking
+	CancelBooking(ctx context.Context, in *CancelBookingRequest, opts ...grpc.CallOption) (*Booking, error)
+	// Hold an available bike for the caller for a limited time
+	ReserveBike(ctx context.Context, in *ReserveBikeRequest, opts ...grpc.CallOption) (*Reservation, error)
+	// Get a reservation by ID
+	GetReservation(ctx context.Context, in *GetReservationRequest, opts ...grpc.CallOption) (*Reservation, error)
+	// Cancel an active reservation and make its bike available again
+	CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*Reservation, error)
+	// Preview what renting a bike for a number of minutes would cost
+	QuoteRental(ctx context.Context, in *QuoteRentalRequest, opts ...grpc.CallOption) (*QuoteRentalReply, error)
+	// End an ongoing rental and make its bike available again
+	EndRental(ctx context.Context, in *EndRentalRequest, opts ...grpc.CallOption) (*Rental, error)
 	// Delete a rental
 	DeleteRental(ctx context.Context, in *DeleteRentalRequest, opts ...grpc.CallOption) (*DeletedRentalResponse, error)
 	// List rentals with pagination
 	ListRentals(ctx context.Context, in *ListRentalsRequest, opts ...grpc.CallOption) (*ListRentalsResponse, error)
+	// List the caller's own rentals, newest first
+	ListMyRentals(ctx context.Context, in *ListMyRentalsRequest, opts ...grpc.CallOption) (*ListRentalsResponse, error)
 }
 
 type rentalServiceClient struct {
@@ -344,6 +408,76 @@ func (c *rentalServiceClient) UpdateRental(ctx context.Context, in *UpdateRental
 	return out, nil
 }
 
+func (c *rentalServiceClient) CreateBooking(ctx context.Context, in *CreateBookingRequest, opts ...grpc.CallOption) (*Booking, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Booking)
+	err := c.cc.Invoke(ctx, RentalService_CreateBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) CancelBooking(ctx context.Context, in *CancelBookingRequest, opts ...grpc.CallOption) (*Booking, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Booking)
+	err := c.cc.Invoke(ctx, RentalService_CancelBooking_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) ReserveBike(ctx context.Context, in *ReserveBikeRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, RentalService_ReserveBike_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) GetReservation(ctx context.Context, in *GetReservationRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, RentalService_GetReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) CancelReservation(ctx context.Context, in *CancelReservationRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, RentalService_CancelReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) QuoteRental(ctx context.Context, in *QuoteRentalRequest, opts ...grpc.CallOption) (*QuoteRentalReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QuoteRentalReply)
+	err := c.cc.Invoke(ctx, RentalService_QuoteRental_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rentalServiceClient) EndRental(ctx context.Context, in *EndRentalRequest, opts ...grpc.CallOption) (*Rental, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Rental)
+	err := c.cc.Invoke(ctx, RentalService_EndRental_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rentalServiceClient) DeleteRental(ctx context.Context, in *DeleteRentalRequest, opts ...grpc.CallOption) (*DeletedRentalResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeletedRentalResponse)
@@ -364,6 +498,16 @@ func (c *rentalServiceClient) ListRentals(ctx context.Context, in *ListRentalsRe
 	return out, nil
 }
 
+func (c *rentalServiceClient) ListMyRentals(ctx context.Context, in *ListMyRentalsRequest, opts ...grpc.CallOption) (*ListRentalsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRentalsResponse)
+	err := c.cc.Invoke(ctx, RentalService_ListMyRentals_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RentalServiceServer is the server API for RentalService service.
 // All implementations must embed UnimplementedRentalServiceServer
 // for forward compatibility.
@@ -374,10 +518,26 @@ type RentalServiceServer interface {
 	GetRental(context.Context, *GetRentalRequest) (*Rental, error)
 	// Update a rental
 	UpdateRental(context.Context, *UpdateRentalRequest) (*Rental, error)
+	// Book a bike for a future time range
+	CreateBooking(context.Context, *CreateBookingRequest) (*Booking, error)
+	// Cancel a booking
+	CancelBooking(context.Context, *CancelBookingRequest) (*Booking, error)
+	// Hold an available bike for the caller for a limited time
+	ReserveBike(context.Context, *ReserveBikeRequest) (*Reservation, error)
+	// Get a reservation by ID
+	GetReservation(context.Context, *GetReservationRequest) (*Reservation, error)
+	// Cancel an active reservation and make its bike available again
+	CancelReservation(context.Context, *CancelReservationRequest) (*Reservation, error)
+	// Preview what renting a bike for a number of minutes would cost
+	QuoteRental(context.Context, *QuoteRentalRequest) (*QuoteRentalReply, error)
+	// End an ongoing rental and make its bike available again
+	EndRental(context.Context, *EndRentalRequest) (*Rental, error)
 	// Delete a rental
 	DeleteRental(context.Context, *DeleteRentalRequest) (*DeletedRentalResponse, error)
 	// List rentals with pagination
 	ListRentals(context.Context, *ListRentalsRequest) (*ListRentalsResponse, error)
+	// List the caller's own rentals, newest first
+	ListMyRentals(context.Context, *ListMyRentalsRequest) (*ListRentalsResponse, error)
 	mustEmbedUnimplementedRentalServiceServer()
 }
 
@@ -397,12 +557,36 @@ func (UnimplementedRentalServiceServer) GetRental(context.Context, *GetRentalReq
 func (UnimplementedRentalServiceServer) UpdateRental(context.Context, *UpdateRentalRequest) (*Rental, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateRental not implemented")
 }
+func (UnimplementedRentalServiceServer) CreateBooking(context.Context, *CreateBookingRequest) (*Booking, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBooking not implemented")
+}
+func (UnimplementedRentalServiceServer) CancelBooking(context.Context, *CancelBookingRequest) (*Booking, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelBooking not implemented")
+}
+func (UnimplementedRentalServiceServer) ReserveBike(context.Context, *ReserveBikeRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveBike not implemented")
+}
+func (UnimplementedRentalServiceServer) GetReservation(context.Context, *GetReservationRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReservation not implemented")
+}
+func (UnimplementedRentalServiceServer) CancelReservation(context.Context, *CancelReservationRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReservation not implemented")
+}
+func (UnimplementedRentalServiceServer) QuoteRental(context.Context, *QuoteRentalRequest) (*QuoteRentalReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteRental not implemented")
+}
+func (UnimplementedRentalServiceServer) EndRental(context.Context, *EndRentalRequest) (*Rental, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EndRental not implemented")
+}
 func (UnimplementedRentalServiceServer) DeleteRental(context.Context, *DeleteRentalRequest) (*DeletedRentalResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteRental not implemented")
 }
 func (UnimplementedRentalServiceServer) ListRentals(context.Context, *ListRentalsRequest) (*ListRentalsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListRentals not implemented")
 }
+func (UnimplementedRentalServiceServer) ListMyRentals(context.Context, *ListMyRentalsRequest) (*ListRentalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyRentals not implemented")
+}
 func (UnimplementedRentalServiceServer) mustEmbedUnimplementedRentalServiceServer() {}
 func (UnimplementedRentalServiceServer) testEmbeddedByValue()                       {}
 
@@ -478,6 +662,132 @@ func _RentalService_UpdateRental_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RentalService_CreateBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).CreateBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_CreateBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).CreateBooking(ctx, req.(*CreateBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_CancelBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelBookingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).CancelBooking(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_CancelBooking_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).CancelBooking(ctx, req.(*CancelBookingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_ReserveBike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveBikeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).ReserveBike(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_ReserveBike_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).ReserveBike(ctx, req.(*ReserveBikeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_GetReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).GetReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_GetReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).GetReservation(ctx, req.(*GetReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_CancelReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).CancelReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_CancelReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).CancelReservation(ctx, req.(*CancelReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_QuoteRental_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteRentalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).QuoteRental(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_QuoteRental_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).QuoteRental(ctx, req.(*QuoteRentalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RentalService_EndRental_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EndRentalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).EndRental(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_EndRental_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).EndRental(ctx, req.(*EndRentalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RentalService_DeleteRental_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRentalRequest)
 	if err := dec(in); err != nil {
@@ -514,6 +824,24 @@ func _RentalService_ListRentals_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RentalService_ListMyRentals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyRentalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RentalServiceServer).ListMyRentals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RentalService_ListMyRentals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RentalServiceServer).ListMyRentals(ctx, req.(*ListMyRentalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RentalService_ServiceDesc is the grpc.ServiceDesc for RentalService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -533,6 +861,34 @@ var RentalService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateRental",
 			Handler:    _RentalService_UpdateRental_Handler,
 		},
+		{
+			MethodName: "CreateBooking",
+			Handler:    _RentalService_CreateBooking_Handler,
+		},
+		{
+			MethodName: "CancelBooking",
+			Handler:    _RentalService_CancelBooking_Handler,
+		},
+		{
+			MethodName: "ReserveBike",
+			Handler:    _RentalService_ReserveBike_Handler,
+		},
+		{
+			MethodName: "GetReservation",
+			Handler:    _RentalService_GetReservation_Handler,
+		},
+		{
+			MethodName: "CancelReservation",
+			Handler:    _RentalService_CancelReservation_Handler,
+		},
+		{
+			MethodName: "QuoteRental",
+			Handler:    _RentalService_QuoteRental_Handler,
+		},
+		{
+			MethodName: "EndRental",
+			Handler:    _RentalService_EndRental_Handler,
+		},
 		{
 			MethodName: "DeleteRental",
 			Handler:    _RentalService_DeleteRental_Handler,
@@ -541,6 +897,10 @@ var RentalService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListRentals",
 			Handler:    _RentalService_ListRentals_Handler,
 		},
+		{
+			MethodName: "ListMyRentals",
+			Handler:    _RentalService_ListMyRentals_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rental.proto",
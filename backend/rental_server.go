@@ -3,20 +3,181 @@ package backend
 import (
 	"context"
 	"db"
+	"errors"
 	"fmt"
+	"log"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// Rental statuses. Rentals created before EndRental existed may carry
+// other values set through UpdateRental.
+const (
+	RentalOngoing   = "ONGOING"
+	RentalCompleted = "COMPLETED"
+)
+
+const (
+	defaultRentalPageSize = 50
+	maxRentalPageSize     = 100
+	// maxQuoteMinutes limits quotes to 31 days
+	maxQuoteMinutes = 31 * 24 * 60
+)
+
 type RentalServer struct {
 	UnimplementedRentalServiceServer
 	PrismaClient *db.PrismaClient
 }
 
+func toRental(rental *db.RentalModel) *Rental {
+	reply := &Rental{
+		Id:        int32(rental.ID),
+		UserId:    int32(rental.UserID),
+		BikeId:    int32(rental.BikeID),
+		StartTime: timestamppb.New(rental.StartTime),
+		Status:    rental.Status,
+	}
+	if t, ok := rental.EndTime(); ok {
+		reply.EndTime = timestamppb.New(t)
+	}
+	if d, ok := rental.DurationSeconds(); ok {
+		reply.DurationSeconds = int32(d)
+	}
+	if c, ok := rental.CostCents(); ok {
+		reply.CostCents = centsToInt32(c)
+	}
+	if id, ok := rental.StartStationID(); ok {
+		reply.StartStationId = int32(id)
+	}
+	if id, ok := rental.ReturnStationID(); ok {
+		reply.ReturnStationId = int32(id)
+	}
+	if id, ok := rental.TariffID(); ok {
+		reply.TariffId = int32(id)
+	}
+	return reply
+}
+
+// findRental loads a rental and makes sure riders can only reach their own.
+// Operators and admins may access any rental.
+func (server *RentalServer) findRental(ctx context.Context, id int) (*db.RentalModel, error) {
+	email, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rental, err := server.PrismaClient.Rental.FindUnique(
+		db.Rental.ID.Equals(id),
+	).With(
+		db.Rental.User.Fetch(),
+		db.Rental.Bike.Fetch(),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "rental %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !IsStaff(CurrentRole(ctx)) && rental.User().Email != email {
+		return nil, status.Error(codes.PermissionDenied, "rental belongs to another user")
+	}
+	return rental, nil
+}
+
+// startRental rents bike bikeID to userID. Flipping the bike to RENTED and
+// creating the Rental happen in one transaction, and the bike update only
+// matches while the bike still has the status it was read with, so of
+// several concurrent requests for the same bike exactly one succeeds. A
+// bike userID reserved converts the reservation in the same transaction.
+// Bikes another rider booked from within BookingBuffer are refused.
+// The bike leaves its dock, which is recorded as the rental's start
+// station, and the tariff valid now is recorded for EndRental to charge.
+func startRental(ctx context.Context, client *db.PrismaClient, userID int, bikeID int) (*db.RentalModel, error) {
+	bike, err := client.Bike.FindUnique(
+		db.Bike.ID.Equals(bikeID),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "bike %d not found", bikeID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	bike, reservation, err := currentReservation(ctx, client, bike)
+	if err != nil {
+		return nil, err
+	}
+	from := bikeStatus(bike)
+	switch {
+	case from == BikeStatus_AVAILABLE:
+	case reservation != nil && reservation.UserID == userID:
+	case reservation != nil:
+		return nil, status.Errorf(codes.FailedPrecondition, "bike %d is reserved by another rider", bikeID)
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "bike %d is %s", bikeID, from)
+	}
+	if err := checkUpcomingBookings(ctx, client, bikeID, userID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	txs := []db.PrismaTransaction{
+		client.Bike.FindUnique(
+			db.Bike.IDStatus(
+				db.Bike.ID.Equals(bikeID),
+				db.Bike.Status.Equals(from.String()),
+			),
+		).Update(
+			db.Bike.Status.Set(BikeStatus_RENTED.String()),
+			db.Bike.StationID.SetOptional(nil),
+			db.Bike.DockID.SetOptional(nil),
+		).Tx(),
+	}
+	var start []db.RentalSetParam
+	tariff, err := tariffFor(ctx, client, bike.Type, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if tariff.Id != 0 {
+		start = append(start, db.Rental.Tariff.Link(db.Tariff.ID.Equals(int(tariff.Id))))
+	}
+	if stationID, ok := bike.StationID(); ok {
+		start = append(start, db.Rental.StartStation.Link(db.Station.ID.Equals(stationID)))
+	}
+	if reservation != nil {
+		txs = append(txs, client.Reservation.FindUnique(
+			db.Reservation.IDStatus(
+				db.Reservation.ID.Equals(reservation.ID),
+				db.Reservation.Status.Equals(ReservationActive),
+			),
+		).Update(
+			db.Reservation.Status.Set(ReservationConverted),
+			db.Reservation.EndedAt.Set(time.Now()),
+		).Tx())
+		start = append(start, db.Rental.Reservation.Link(db.Reservation.ID.Equals(reservation.ID)))
+	}
+	createRental := client.Rental.CreateOne(
+		db.Rental.User.Link(db.User.ID.Equals(userID)),
+		db.Rental.Bike.Link(db.Bike.ID.Equals(bikeID)),
+		start...,
+	).Tx()
+	txs = append(txs, createRental)
+	if err := client.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+		// the transaction fails as a whole, find out whether another
+		// request got the bike first
+		if current, findErr := client.Bike.FindUnique(db.Bike.ID.Equals(bikeID)).Exec(ctx); findErr == nil && bikeStatus(current) != from {
+			return nil, status.Errorf(codes.FailedPrecondition, "bike %d is %s", bikeID, bikeStatus(current))
+		}
+		log.Printf("Could not start rental of bike %d: %v", bikeID, err)
+		return nil, status.Error(codes.Internal, "could not start rental")
+	}
+	return createRental.Result(), nil
+}
+
 /*
 	curl -X POST http://localhost:8080/v1/rentals \
 	  -H 'Content-Type: application/json' \
-	  -H 'Authorization: $TOKEN' \
+	  -H 'Authorization: Bearer $TOKEN' \
 	  -d '{
 	        "bike_id": 1
 	      }'
@@ -32,122 +193,285 @@ func (server *RentalServer) CreateRental(ctx context.Context, req *CreateRentalR
 	if err != nil {
 		return nil, err
 	}
-	result, err := server.PrismaClient.Rental.CreateOne(
-		db.Rental.User.Link(db.User.ID.Equals(int(user.ID))),
-		db.Rental.Bike.Link(db.Bike.ID.Equals(int(req.BikeId))),
-	).Exec(ctx)
-	if err != nil {
+	if err := requireVerifiedEmail(user); err != nil {
 		return nil, err
 	}
-
-	// Map the result to your Rental type if necessary
-	rental := &Rental{
-		Id:     int32(result.ID),
-		UserId: int32(result.UserID),
-		BikeId: int32(result.BikeID),
-		Status: "Ongoing",
-		// Add other fields as needed
+	result, err := startRental(ctx, server.PrismaClient, user.ID, int(req.BikeId))
+	if err != nil {
+		return nil, err
 	}
-
-	return rental, nil
+	return toRental(result), nil
 }
 
 /*
 	curl -X GET http://localhost:8080/v1/rentals/1 \
-	  -H 'Authorization: $TOKEN'
+	  -H 'Authorization: Bearer $TOKEN'
 */
 func (server *RentalServer) GetRental(ctx context.Context, req *GetRentalRequest) (*Rental, error) {
-	result, err := server.PrismaClient.Rental.FindUnique(
-		db.Rental.ID.Equals(int(req.Id)),
+	result, err := server.findRental(ctx, int(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	return toRental(result), nil
+}
+
+/*
+	curl -X GET 'http://localhost:8080/v1/bikes/1/quote?minutes=30' \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *RentalServer) QuoteRental(ctx context.Context, req *QuoteRentalRequest) (*QuoteRentalReply, error) {
+	if req.Minutes < 0 || req.Minutes > maxQuoteMinutes {
+		return nil, status.Errorf(codes.InvalidArgument, "minutes must be between 0 and %d", maxQuoteMinutes)
+	}
+	bike, err := server.PrismaClient.Bike.FindUnique(
+		db.Bike.ID.Equals(int(req.BikeId)),
 	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "bike %d not found", req.BikeId)
+	}
 	if err != nil {
 		return nil, err
 	}
-	t, _ := result.EndTime()
-	return &Rental{
-		Id:      int32(result.ID),
-		UserId:  int32(result.UserID),
-		BikeId:  int32(result.BikeID),
-		EndTime: timestamppb.New(t),
+	start := time.Now()
+	if req.StartTime != nil {
+		start = req.StartTime.AsTime()
+	}
+	tariff, err := tariffFor(ctx, server.PrismaClient, bike.Type, start)
+	if err != nil {
+		return nil, err
+	}
+	end := start.Add(time.Duration(req.Minutes) * time.Minute)
+	return &QuoteRentalReply{
+		CostCents:     centsToInt32(PriceRental(tariff, start, end)),
+		TariffId:      tariff.Id,
+		TariffVersion: tariff.Version,
 	}, nil
 }
 
+/*
+	curl -X POST http://localhost:8080/v1/rentals/1/end \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{
+	        "return_dock_id": 12
+	      }'
+*/
+func (server *RentalServer) EndRental(ctx context.Context, req *EndRentalRequest) (*Rental, error) {
+	rental, err := server.findRental(ctx, int(req.RentalId))
+	if err != nil {
+		return nil, err
+	}
+	if _, ended := rental.EndTime(); ended || rental.Status != RentalOngoing {
+		return nil, status.Errorf(codes.FailedPrecondition, "rental %d has already ended", rental.ID)
+	}
+
+	// a dock implies its station, a station alone means the bike was left
+	// next to it
+	stationID, dockID := int(req.ReturnStationId), int(req.ReturnDockId)
+	if dockID != 0 {
+		dock, err := freeDock(ctx, server.PrismaClient, dockID)
+		if err != nil {
+			return nil, err
+		}
+		if stationID != 0 && stationID != dock.StationID {
+			return nil, status.Errorf(codes.InvalidArgument, "dock %d is not at station %d", dockID, stationID)
+		}
+		stationID = dock.StationID
+	} else if stationID != 0 {
+		_, err := server.PrismaClient.Station.FindUnique(db.Station.ID.Equals(stationID)).Exec(ctx)
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "station %d not found", stationID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// riders pay what was advertised when they took the bike, even if a
+	// new tariff was published during the ride
+	tariff, err := rentalTariff(ctx, server.PrismaClient, rental)
+	if err != nil {
+		return nil, err
+	}
+	end := time.Now()
+	update := []db.RentalSetParam{
+		db.Rental.EndTime.Set(end),
+		db.Rental.Status.Set(RentalCompleted),
+		db.Rental.DurationSeconds.Set(int(end.Sub(rental.StartTime) / time.Second)),
+		db.Rental.CostCents.Set(PriceRental(tariff, rental.StartTime, end)),
+	}
+	bikeUpdate := []db.BikeSetParam{
+		db.Bike.Status.Set(BikeStatus_AVAILABLE.String()),
+	}
+	if stationID != 0 {
+		update = append(update, db.Rental.ReturnStation.Link(db.Station.ID.Equals(stationID)))
+		bikeUpdate = append(bikeUpdate, db.Bike.StationID.Set(stationID))
+	}
+	if dockID != 0 {
+		bikeUpdate = append(bikeUpdate, db.Bike.DockID.Set(dockID))
+	}
+	// matching on ONGOING makes the transaction fail if the rental was
+	// ended concurrently, so the bike is only released once
+	endRental := server.PrismaClient.Rental.FindUnique(
+		db.Rental.IDStatus(
+			db.Rental.ID.Equals(rental.ID),
+			db.Rental.Status.Equals(RentalOngoing),
+		),
+	).Update(update...).Tx()
+	release := server.PrismaClient.Bike.FindMany(
+		db.Bike.ID.Equals(rental.BikeID),
+		db.Bike.Status.Equals(BikeStatus_RENTED.String()),
+	).Update(bikeUpdate...).Tx()
+	if err := server.PrismaClient.Prisma.Transaction(endRental, release).Exec(ctx); err != nil {
+		if _, ok := db.IsErrUniqueConstraint(err); ok {
+			return nil, status.Errorf(codes.FailedPrecondition, "dock %d is occupied", dockID)
+		}
+		if current, findErr := server.PrismaClient.Rental.FindUnique(db.Rental.ID.Equals(rental.ID)).Exec(ctx); findErr == nil && current.Status != RentalOngoing {
+			return nil, status.Errorf(codes.FailedPrecondition, "rental %d has already ended", rental.ID)
+		}
+		log.Printf("Could not end rental %d: %v", rental.ID, err)
+		return nil, status.Error(codes.Internal, "could not end rental")
+	}
+	return toRental(endRental.Result()), nil
+}
+
 /*
 	curl -X DELETE http://localhost:8080/v1/rentals/1 \
-	  -H 'Authorization: $TOKEN'
+	  -H 'Authorization: Bearer $TOKEN'
 */
 func (server *RentalServer) DeleteRental(ctx context.Context, req *DeleteRentalRequest) (*DeletedRentalResponse, error) {
-	rental, err := server.PrismaClient.Rental.FindUnique(
-		db.Rental.ID.Equals(int(req.Id)),
+	if !IsStaff(CurrentRole(ctx)) {
+		return nil, status.Error(codes.PermissionDenied, "only staff can delete rentals")
+	}
+	rental, err := server.findRental(ctx, int(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	if rental.Status == RentalOngoing {
+		return nil, status.Errorf(codes.FailedPrecondition, "rental %d is ongoing, end it with EndRental first", rental.ID)
+	}
+	_, err = server.PrismaClient.Rental.FindUnique(
+		db.Rental.ID.Equals(rental.ID),
 	).Delete().Exec(ctx)
 	if err != nil {
 		return nil, err
 	}
-	message := fmt.Sprintf("Bike %d was deleted!", rental.ID)
 	return &DeletedRentalResponse{
-		Message: message,
+		Message: fmt.Sprintf("Rental %d was deleted!", rental.ID),
 	}, nil
 }
 
 /*
 	curl -X PUT http://localhost:8080/v1/rentals/1 \
 	  -H 'Content-Type: application/json' \
-	  -H 'Authorization: $TOKEN' \
+	  -H 'Authorization: Bearer $TOKEN' \
 	  -d '{
-	        "bike_id": 2,
-	        "end_time": "2023-10-01T15:30:00Z",
-	        "status": "completed"
+	        "end_time": "2023-10-01T15:30:00Z"
 	      }'
 */
+// UpdateRental lets staff correct the end time of a completed rental, which
+// reprices it by its tariff. Rentals are ended with EndRental only, so the
+// bike is returned and the ride is charged.
 func (server *RentalServer) UpdateRental(ctx context.Context, req *UpdateRentalRequest) (*Rental, error) {
-	email, err := CurrentUser(ctx)
-	user, err := server.PrismaClient.User.FindUnique(
-		db.User.Email.Equals(email),
-	).Exec(ctx)
+	if !IsStaff(CurrentRole(ctx)) {
+		return nil, status.Error(codes.PermissionDenied, "only staff can correct rentals")
+	}
+	rental, err := server.findRental(ctx, int(req.Id))
+	if err != nil {
+		return nil, err
+	}
+	if rental.Status != RentalCompleted {
+		return nil, status.Errorf(codes.FailedPrecondition, "rental %d is %s, end it with EndRental", rental.ID, rental.Status)
+	}
+	if req.Status != "" && req.Status != RentalCompleted {
+		return nil, status.Errorf(codes.InvalidArgument, "status can only be %s", RentalCompleted)
+	}
+	if req.EndTime == nil {
+		return toRental(rental), nil
+	}
+	end := req.EndTime.AsTime()
+	if end.Before(rental.StartTime) || end.After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "end_time must be between the start of the rental and now")
+	}
+	tariff, err := rentalTariff(ctx, server.PrismaClient, rental)
 	if err != nil {
 		return nil, err
 	}
 
 	result, err := server.PrismaClient.Rental.FindUnique(
-		db.Rental.ID.Equals(user.ID),
+		db.Rental.ID.Equals(rental.ID),
 	).Update(
-		db.Rental.Status.Set(req.Status),
-		db.Rental.EndTime.Set(req.EndTime.AsTime()),
+		db.Rental.EndTime.Set(end),
+		db.Rental.DurationSeconds.Set(int(end.Sub(rental.StartTime)/time.Second)),
+		db.Rental.CostCents.Set(PriceRental(tariff, rental.StartTime, end)),
 	).Exec(ctx)
 	if err != nil {
 		return nil, err
 	}
-	time, _ := result.EndTime()
-
-	return &Rental{
-		Id:      int32(result.ID),
-		UserId:  int32(result.UserID),
-		BikeId:  int32(result.BikeID),
-		EndTime: timestamppb.New(time),
-		Status:  result.Status,
-		// Add other fields as needed
-	}, nil
+	return toRental(result), nil
 }
 
 /*
 	curl -X GET 'http://localhost:8080/v1/rentals?page=1&page_size=10' \
-	  -H 'Authorization: $TOKEN'
+	  -H 'Authorization: Bearer $TOKEN'
 */
 func (server *RentalServer) ListRentals(ctx context.Context, req *ListRentalsRequest) (*ListRentalsResponse, error) {
-	selected, err := server.PrismaClient.Rental.FindMany().Take(int(req.PageSize)).Skip((int(req.Page) - 1) * int(req.PageSize)).Exec(ctx)
+	email, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var filters []db.RentalWhereParam
+	if !IsStaff(CurrentRole(ctx)) {
+		filters = append(filters, db.Rental.User.Where(db.User.Email.Equals(email)))
+	}
+	take, skip := paginate(req.Page, req.PageSize, defaultRentalPageSize, maxRentalPageSize)
+	return server.listRentals(ctx, filters, take, skip)
+}
+
+/*
+	curl -X GET 'http://localhost:8080/v1/users/me/rentals?status=COMPLETED&started_after=2024-01-01T00:00:00Z&page=1&page_size=10' \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *RentalServer) ListMyRentals(ctx context.Context, req *ListMyRentalsRequest) (*ListRentalsResponse, error) {
+	email, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// unlike ListRentals this is limited to the caller for staff too
+	filters := []db.RentalWhereParam{
+		db.Rental.User.Where(db.User.Email.Equals(email)),
+	}
+	switch req.Status {
+	case "":
+	case RentalOngoing:
+		filters = append(filters, db.Rental.EndTime.IsNull())
+	case RentalCompleted:
+		// rentals ended through UpdateRental have other statuses
+		filters = append(filters, db.Rental.Not(db.Rental.EndTime.IsNull()))
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "status must be %s or %s", RentalOngoing, RentalCompleted)
+	}
+	if req.StartedAfter != nil {
+		filters = append(filters, db.Rental.StartTime.Gte(req.StartedAfter.AsTime()))
+	}
+	if req.StartedBefore != nil {
+		filters = append(filters, db.Rental.StartTime.Lt(req.StartedBefore.AsTime()))
+	}
+	take, skip := paginate(req.Page, req.PageSize, defaultRentalPageSize, maxRentalPageSize)
+	return server.listRentals(ctx, filters, take, skip)
+}
+
+func (server *RentalServer) listRentals(ctx context.Context, filters []db.RentalWhereParam, take, skip int) (*ListRentalsResponse, error) {
+	selected, err := server.PrismaClient.Rental.FindMany(filters...).OrderBy(
+		db.Rental.StartTime.Order(db.SortOrderDesc),
+		db.Rental.ID.Order(db.SortOrderDesc),
+	).Take(take).Skip(skip).Exec(ctx)
 	if err != nil {
 		return nil, err
 	}
 	var rentals []*Rental
-	for _, rental := range selected {
-		end, _ := rental.EndTime()
-		rentals = append(rentals, &Rental{
-			Id:      int32(rental.ID),
-			UserId:  int32(rental.UserID),
-			BikeId:  int32(rental.BikeID),
-			Status:  rental.Status,
-			EndTime: timestamppb.New(end),
-		})
+	for i := range selected {
+		rentals = append(rentals, toRental(&selected[i]))
 	}
 	return &ListRentalsResponse{
 		Rentals: rentals,
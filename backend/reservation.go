@@ -0,0 +1,270 @@
+package backend
+
+import (
+	"context"
+	"db"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Reservation statuses.
+const (
+	ReservationActive    = "ACTIVE"
+	ReservationCancelled = "CANCELLED"
+	ReservationExpired   = "EXPIRED"
+	ReservationConverted = "CONVERTED"
+)
+
+// ReservationTTL is how long a reservation holds a bike. Override with
+// RESERVATION_TTL.
+var ReservationTTL = durationFromEnv("RESERVATION_TTL", 15*time.Minute)
+
+func toReservation(reservation *db.ReservationModel) *Reservation {
+	reply := &Reservation{
+		Id:        int32(reservation.ID),
+		UserId:    int32(reservation.UserID),
+		BikeId:    int32(reservation.BikeID),
+		Status:    reservation.Status,
+		ExpiresAt: timestamppb.New(reservation.ExpiresAt),
+		CreatedAt: timestamppb.New(reservation.CreatedAt),
+	}
+	if t, ok := reservation.EndedAt(); ok {
+		reply.EndedAt = timestamppb.New(t)
+	}
+	if rental, ok := reservation.Rental(); ok {
+		reply.RentalId = int32(rental.ID)
+	}
+	return reply
+}
+
+// activeReservation returns the active reservation of bikeID, or nil.
+func activeReservation(ctx context.Context, client *db.PrismaClient, bikeID int) (*db.ReservationModel, error) {
+	reservation, err := client.Reservation.FindFirst(
+		db.Reservation.BikeID.Equals(bikeID),
+		db.Reservation.Status.Equals(ReservationActive),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, nil
+	}
+	return reservation, err
+}
+
+// currentReservation returns the active reservation holding bike, or nil.
+// A reservation whose time is up is expired right away instead of waiting
+// for ExpireReservations, and the reloaded bike is returned with it.
+func currentReservation(ctx context.Context, client *db.PrismaClient, bike *db.BikeModel) (*db.BikeModel, *db.ReservationModel, error) {
+	if bikeStatus(bike) != BikeStatus_RESERVED {
+		return bike, nil, nil
+	}
+	reservation, err := activeReservation(ctx, client, bike.ID)
+	if err != nil || reservation == nil {
+		return bike, nil, err
+	}
+	if time.Now().Before(reservation.ExpiresAt) {
+		return bike, reservation, nil
+	}
+	if _, err := endReservation(ctx, client, reservation, ReservationExpired); err != nil && status.Code(err) != codes.FailedPrecondition {
+		return nil, nil, err
+	}
+	bike, err = client.Bike.FindUnique(
+		db.Bike.ID.Equals(bike.ID),
+	).Exec(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return currentReservation(ctx, client, bike)
+}
+
+// endReservation moves an active reservation to status to and makes its
+// bike available again. The reservation update only matches while it is
+// ACTIVE, so a reservation that is cancelled, expired and converted at the
+// same time ends only once; the others get FailedPrecondition.
+func endReservation(ctx context.Context, client *db.PrismaClient, reservation *db.ReservationModel, to string) (*db.ReservationModel, error) {
+	end := client.Reservation.FindUnique(
+		db.Reservation.IDStatus(
+			db.Reservation.ID.Equals(reservation.ID),
+			db.Reservation.Status.Equals(ReservationActive),
+		),
+	).Update(
+		db.Reservation.Status.Set(to),
+		db.Reservation.EndedAt.Set(time.Now()),
+	).Tx()
+	release := client.Bike.FindMany(
+		db.Bike.ID.Equals(reservation.BikeID),
+		db.Bike.Status.Equals(BikeStatus_RESERVED.String()),
+	).Update(
+		db.Bike.Status.Set(BikeStatus_AVAILABLE.String()),
+	).Tx()
+	if err := client.Prisma.Transaction(end, release).Exec(ctx); err != nil {
+		if current, findErr := client.Reservation.FindUnique(db.Reservation.ID.Equals(reservation.ID)).Exec(ctx); findErr == nil && current.Status != ReservationActive {
+			return nil, status.Errorf(codes.FailedPrecondition, "reservation %d is %s", reservation.ID, current.Status)
+		}
+		return nil, err
+	}
+	return end.Result(), nil
+}
+
+// ExpireReservations ends the active reservations whose time is up and
+// returns how many it ended. Reservations live in the database, so ones
+// that lapsed while the server was down are ended on the next run.
+func ExpireReservations(ctx context.Context, client *db.PrismaClient) (int, error) {
+	lapsed, err := client.Reservation.FindMany(
+		db.Reservation.Status.Equals(ReservationActive),
+		db.Reservation.ExpiresAt.Lte(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	expired := 0
+	for i := range lapsed {
+		_, err := endReservation(ctx, client, &lapsed[i], ReservationExpired)
+		if status.Code(err) == codes.FailedPrecondition {
+			// cancelled or converted in the meantime
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+func (server *RentalServer) findReservation(ctx context.Context, id int32) (*db.ReservationModel, error) {
+	email, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reservation, err := server.PrismaClient.Reservation.FindUnique(
+		db.Reservation.ID.Equals(int(id)),
+	).With(
+		db.Reservation.User.Fetch(),
+		db.Reservation.Rental.Fetch(),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "reservation %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !IsStaff(CurrentRole(ctx)) && reservation.User().Email != email {
+		return nil, status.Error(codes.PermissionDenied, "reservation belongs to another user")
+	}
+	return reservation, nil
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/reservations \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{
+	        "bike_id": 1
+	      }'
+*/
+func (server *RentalServer) ReserveBike(ctx context.Context, req *ReserveBikeRequest) (*Reservation, error) {
+	email, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := server.PrismaClient.User.FindUnique(
+		db.User.Email.Equals(email),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireVerifiedEmail(user); err != nil {
+		return nil, err
+	}
+	held, err := server.PrismaClient.Reservation.FindMany(
+		db.Reservation.UserID.Equals(user.ID),
+		db.Reservation.Status.Equals(ReservationActive),
+		db.Reservation.ExpiresAt.Gt(time.Now()),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(held) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "you already hold bike %d, cancel that reservation first", held[0].BikeID)
+	}
+
+	bikeID := int(req.BikeId)
+	bike, err := server.PrismaClient.Bike.FindUnique(
+		db.Bike.ID.Equals(bikeID),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "bike %d not found", bikeID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bike, _, err = currentReservation(ctx, server.PrismaClient, bike); err != nil {
+		return nil, err
+	}
+	if from := bikeStatus(bike); from != BikeStatus_AVAILABLE {
+		return nil, status.Errorf(codes.FailedPrecondition, "bike %d is %s", bikeID, from)
+	}
+	if err := checkUpcomingBookings(ctx, server.PrismaClient, bikeID, user.ID, time.Now().Add(ReservationTTL)); err != nil {
+		return nil, err
+	}
+
+	// same compare-and-swap on the bike's status as startRental
+	reserveBike := server.PrismaClient.Bike.FindUnique(
+		db.Bike.IDStatus(
+			db.Bike.ID.Equals(bikeID),
+			db.Bike.Status.Equals(BikeStatus_AVAILABLE.String()),
+		),
+	).Update(
+		db.Bike.Status.Set(BikeStatus_RESERVED.String()),
+	).Tx()
+	createReservation := server.PrismaClient.Reservation.CreateOne(
+		db.Reservation.User.Link(db.User.ID.Equals(user.ID)),
+		db.Reservation.Bike.Link(db.Bike.ID.Equals(bikeID)),
+		db.Reservation.ExpiresAt.Set(time.Now().Add(ReservationTTL)),
+	).Tx()
+	if err := server.PrismaClient.Prisma.Transaction(reserveBike, createReservation).Exec(ctx); err != nil {
+		if current, findErr := server.PrismaClient.Bike.FindUnique(db.Bike.ID.Equals(bikeID)).Exec(ctx); findErr == nil && bikeStatus(current) != BikeStatus_AVAILABLE {
+			return nil, status.Errorf(codes.FailedPrecondition, "bike %d is %s", bikeID, bikeStatus(current))
+		}
+		log.Printf("Could not reserve bike %d: %v", bikeID, err)
+		return nil, status.Error(codes.Internal, "could not reserve bike")
+	}
+	return toReservation(createReservation.Result()), nil
+}
+
+/*
+	curl -X GET http://localhost:8080/v1/reservations/1 \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *RentalServer) GetReservation(ctx context.Context, req *GetReservationRequest) (*Reservation, error) {
+	reservation, err := server.findReservation(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return toReservation(reservation), nil
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/reservations/1/cancel \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{}'
+*/
+func (server *RentalServer) CancelReservation(ctx context.Context, req *CancelReservationRequest) (*Reservation, error) {
+	reservation, err := server.findReservation(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if reservation.Status != ReservationActive {
+		return nil, status.Errorf(codes.FailedPrecondition, "reservation %d is %s", reservation.ID, reservation.Status)
+	}
+	ended, err := endReservation(ctx, server.PrismaClient, reservation, ReservationCancelled)
+	if err != nil {
+		return nil, err
+	}
+	return toReservation(ended), nil
+}
@@ -0,0 +1,72 @@
+package backend
+
+import (
+	"backend/auth"
+	"context"
+	"db"
+	"errors"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sessionSeenInterval limits how often the last seen time of a session is
+// written, otherwise every request would need a write.
+const sessionSeenInterval = time.Minute
+
+// checkSession refuses access tokens of sessions that were revoked or
+// have been purged.
+func (s *TokenStore) checkSession(ctx context.Context, sessionID string) error {
+	session, err := s.PrismaClient.Session.FindUnique(
+		db.Session.ID.Equals(sessionID),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return status.Error(codes.Unauthenticated, "session has ended")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "could not check session: %v", err)
+	}
+	if _, revoked := session.RevokedAt(); revoked {
+		return status.Error(codes.Unauthenticated, "session has been revoked")
+	}
+
+	if time.Since(session.LastSeenAt) > sessionSeenInterval {
+		_, err := s.PrismaClient.Session.FindUnique(
+			db.Session.ID.Equals(sessionID),
+		).Update(
+			db.Session.LastSeenAt.Set(time.Now()),
+		).Exec(ctx)
+		if err != nil {
+			log.Printf("Could not record use of session %s: %v", sessionID, err)
+		}
+	}
+	return nil
+}
+
+// touchSession extends the session of a refreshed token family. Families
+// started before sessions existed get one on their first refresh.
+func (s *TokenStore) touchSession(ctx context.Context, userID int, sessionID string) error {
+	now := time.Now()
+	_, err := s.PrismaClient.Session.UpsertOne(
+		db.Session.ID.Equals(sessionID),
+	).Create(
+		db.Session.ID.Set(sessionID),
+		db.Session.User.Link(db.User.ID.Equals(userID)),
+		db.Session.ExpiresAt.Set(now.Add(RefreshTokenTTL)),
+		db.Session.UserAgent.Set(auth.UserAgent(ctx)),
+		db.Session.IP.Set(auth.ClientIP(ctx)),
+	).Update(
+		db.Session.ExpiresAt.Set(now.Add(RefreshTokenTTL)),
+		db.Session.LastSeenAt.Set(now),
+		db.Session.IP.Set(auth.ClientIP(ctx)),
+	).Exec(ctx)
+	return err
+}
+
+// RevokeSession logs sessionID out. Its refresh tokens stop working and so
+// do its access tokens, even before they expire.
+func (s *TokenStore) RevokeSession(ctx context.Context, sessionID string) error {
+	return s.revokeFamily(ctx, sessionID, nil)
+}
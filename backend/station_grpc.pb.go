@@ -0,0 +1,331 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: station.proto
+
+package backend
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	StationService_CreateStation_FullMethodName = "/station.StationService/CreateStation"
+	StationService_GetStation_FullMethodName    = "/station.StationService/GetStation"
+	StationService_UpdateStation_FullMethodName = "/station.StationService/UpdateStation"
+	StationService_DeleteStation_FullMethodName = "/station.StationService/DeleteStation"
+	StationService_ListStations_FullMethodName  = "/station.StationService/ListStations"
+	StationService_SearchNearby_FullMethodName  = "/station.StationService/SearchNearby"
+)
+
+// StationServiceClient is the client API for StationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// StationService manages the stations bikes are docked at. Anyone can
+// read them, changing them is limited to operators and admins.
+type StationServiceClient interface {
+	// Create a station with capacity empty docks
+	CreateStation(ctx context.Context, in *CreateStationRequest, opts ...grpc.CallOption) (*Station, error)
+	// Get a station together with its docks
+	GetStation(ctx context.Context, in *GetStationRequest, opts ...grpc.CallOption) (*Station, error)
+	// Update a station. Lowering the capacity removes the highest
+	// numbered docks, which must be empty.
+	UpdateStation(ctx context.Context, in *UpdateStationRequest, opts ...grpc.CallOption) (*Station, error)
+	// Delete a station that has no bikes left
+	DeleteStation(ctx context.Context, in *DeleteStationRequest, opts ...grpc.CallOption) (*DeleteStationReply, error)
+	// List stations by name
+	ListStations(ctx context.Context, in *ListStationsRequest, opts ...grpc.CallOption) (*ListStationsReply, error)
+	// Find stations and available bikes around a point, nearest first
+	SearchNearby(ctx context.Context, in *SearchNearbyRequest, opts ...grpc.CallOption) (*SearchNearbyReply, error)
+}
+
+type stationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewStationServiceClient(cc grpc.ClientConnInterface) StationServiceClient {
+	return &stationServiceClient{cc}
+}
+
+func (c *stationServiceClient) CreateStation(ctx context.Context, in *CreateStationRequest, opts ...grpc.CallOption) (*Station, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Station)
+	err := c.cc.Invoke(ctx, StationService_CreateStation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stationServiceClient) GetStation(ctx context.Context, in *GetStationRequest, opts ...grpc.CallOption) (*Station, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Station)
+	err := c.cc.Invoke(ctx, StationService_GetStation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stationServiceClient) UpdateStation(ctx context.Context, in *UpdateStationRequest, opts ...grpc.CallOption) (*Station, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Station)
+	err := c.cc.Invoke(ctx, StationService_UpdateStation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stationServiceClient) DeleteStation(ctx context.Context, in *DeleteStationRequest, opts ...grpc.CallOption) (*DeleteStationReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteStationReply)
+	err := c.cc.Invoke(ctx, StationService_DeleteStation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stationServiceClient) ListStations(ctx context.Context, in *ListStationsRequest, opts ...grpc.CallOption) (*ListStationsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStationsReply)
+	err := c.cc.Invoke(ctx, StationService_ListStations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stationServiceClient) SearchNearby(ctx context.Context, in *SearchNearbyRequest, opts ...grpc.CallOption) (*SearchNearbyReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchNearbyReply)
+	err := c.cc.Invoke(ctx, StationService_SearchNearby_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// StationServiceServer is the server API for StationService service.
+// All implementations must embed UnimplementedStationServiceServer
+// for forward compatibility.
+//
+// StationService manages the stations bikes are docked at. Anyone can
+// read them, changing them is limited to operators and admins.
+type StationServiceServer interface {
+	// Create a station with capacity empty docks
+	CreateStation(context.Context, *CreateStationRequest) (*Station, error)
+	// Get a station together with its docks
+	GetStation(context.Context, *GetStationRequest) (*Station, error)
+	// Update a station. Lowering the capacity removes the highest
+	// numbered docks, which must be empty.
+	UpdateStation(context.Context, *UpdateStationRequest) (*Station, error)
+	// Delete a station that has no bikes left
+	DeleteStation(context.Context, *DeleteStationRequest) (*DeleteStationReply, error)
+	// List stations by name
+	ListStations(context.Context, *ListStationsRequest) (*ListStationsReply, error)
+	// Find stations and available bikes around a point, nearest first
+	SearchNearby(context.Context, *SearchNearbyRequest) (*SearchNearbyReply, error)
+	mustEmbedUnimplementedStationServiceServer()
+}
+
+// UnimplementedStationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedStationServiceServer struct{}
+
+func (UnimplementedStationServiceServer) CreateStation(context.Context, *CreateStationRequest) (*Station, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateStation not implemented")
+}
+func (UnimplementedStationServiceServer) GetStation(context.Context, *GetStationRequest) (*Station, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStation not implemented")
+}
+func (UnimplementedStationServiceServer) UpdateStation(context.Context, *UpdateStationRequest) (*Station, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateStation not implemented")
+}
+func (UnimplementedStationServiceServer) DeleteStation(context.Context, *DeleteStationRequest) (*DeleteStationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteStation not implemented")
+}
+func (UnimplementedStationServiceServer) ListStations(context.Context, *ListStationsRequest) (*ListStationsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStations not implemented")
+}
+func (UnimplementedStationServiceServer) SearchNearby(context.Context, *SearchNearbyRequest) (*SearchNearbyReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchNearby not implemented")
+}
+func (UnimplementedStationServiceServer) mustEmbedUnimplementedStationServiceServer() {}
+func (UnimplementedStationServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeStationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to StationServiceServer will
+// result in compilation errors.
+type UnsafeStationServiceServer interface {
+	mustEmbedUnimplementedStationServiceServer()
+}
+
+func RegisterStationServiceServer(s grpc.ServiceRegistrar, srv StationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedStationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&StationService_ServiceDesc, srv)
+}
+
+func _StationService_CreateStation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StationServiceServer).CreateStation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StationService_CreateStation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StationServiceServer).CreateStation(ctx, req.(*CreateStationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StationService_GetStation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StationServiceServer).GetStation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StationService_GetStation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StationServiceServer).GetStation(ctx, req.(*GetStationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StationService_UpdateStation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StationServiceServer).UpdateStation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StationService_UpdateStation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StationServiceServer).UpdateStation(ctx, req.(*UpdateStationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StationService_DeleteStation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteStationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StationServiceServer).DeleteStation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StationService_DeleteStation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StationServiceServer).DeleteStation(ctx, req.(*DeleteStationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StationService_ListStations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StationServiceServer).ListStations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StationService_ListStations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StationServiceServer).ListStations(ctx, req.(*ListStationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StationService_SearchNearby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchNearbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StationServiceServer).SearchNearby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StationService_SearchNearby_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StationServiceServer).SearchNearby(ctx, req.(*SearchNearbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// StationService_ServiceDesc is the grpc.ServiceDesc for StationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var StationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "station.StationService",
+	HandlerType: (*StationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateStation",
+			Handler:    _StationService_CreateStation_Handler,
+		},
+		{
+			MethodName: "GetStation",
+			Handler:    _StationService_GetStation_Handler,
+		},
+		{
+			MethodName: "UpdateStation",
+			Handler:    _StationService_UpdateStation_Handler,
+		},
+		{
+			MethodName: "DeleteStation",
+			Handler:    _StationService_DeleteStation_Handler,
+		},
+		{
+			MethodName: "ListStations",
+			Handler:    _StationService_ListStations_Handler,
+		},
+		{
+			MethodName: "SearchNearby",
+			Handler:    _StationService_SearchNearby_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "station.proto",
+}
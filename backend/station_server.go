@@ -0,0 +1,302 @@
+package backend
+
+import (
+	"context"
+	"db"
+	"errors"
+	"fmt"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultStationPageSize = 50
+	maxStationPageSize     = 200
+	maxStationCapacity     = 200
+)
+
+type StationServer struct {
+	UnimplementedStationServiceServer
+	PrismaClient *db.PrismaClient
+}
+
+// toStation expects the station's docks with their bikes and its available
+// bikes to be fetched.
+func toStation(station *db.StationModel, withDocks bool) *Station {
+	reply := &Station{
+		Id:             int32(station.ID),
+		Name:           station.Name,
+		Address:        station.Address,
+		Latitude:       station.Latitude,
+		Longitude:      station.Longitude,
+		Capacity:       int32(len(station.Docks())),
+		AvailableBikes: int32(len(station.Bikes())),
+		CreatedAt:      timestamppb.New(station.CreatedAt),
+		UpdatedAt:      timestamppb.New(station.UpdatedAt),
+	}
+	for _, dock := range station.Docks() {
+		d := &Dock{Id: int32(dock.ID), Number: int32(dock.Number)}
+		if bike, ok := dock.Bike(); ok {
+			d.BikeId = int32(bike.ID)
+		} else {
+			reply.FreeDocks++
+		}
+		if withDocks {
+			reply.Docks = append(reply.Docks, d)
+		}
+	}
+	return reply
+}
+
+func stationDetails() []db.StationRelationWith {
+	return []db.StationRelationWith{
+		db.Station.Docks.Fetch().With(
+			db.Dock.Bike.Fetch(),
+		).OrderBy(
+			db.Dock.Number.Order(db.SortOrderAsc),
+		),
+		db.Station.Bikes.Fetch(
+			db.Bike.Status.Equals(BikeStatus_AVAILABLE.String()),
+		),
+	}
+}
+
+func (server *StationServer) findStation(ctx context.Context, id int32) (*db.StationModel, error) {
+	station, err := server.PrismaClient.Station.FindUnique(
+		db.Station.ID.Equals(int(id)),
+	).With(stationDetails()...).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "station %d not found", id)
+	}
+	return station, err
+}
+
+func validCoordinates(latitude, longitude float64) error {
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return status.Errorf(codes.InvalidArgument, "invalid coordinates %f, %f", latitude, longitude)
+	}
+	return nil
+}
+
+func validCapacity(capacity int32) error {
+	if capacity < 0 || capacity > maxStationCapacity {
+		return status.Errorf(codes.InvalidArgument, "capacity must be between 0 and %d", maxStationCapacity)
+	}
+	return nil
+}
+
+// addDocks returns the writes numbering new docks of stationID from first
+// up to and including last, to run in one transaction.
+func addDocks(client *db.PrismaClient, stationID int, first, last int) []db.PrismaTransaction {
+	var txs []db.PrismaTransaction
+	for number := first; number <= last; number++ {
+		txs = append(txs, client.Dock.CreateOne(
+			db.Dock.Station.Link(db.Station.ID.Equals(stationID)),
+			db.Dock.Number.Set(number),
+		).Tx())
+	}
+	return txs
+}
+
+// freeDock loads a dock bikes can be put into.
+func freeDock(ctx context.Context, client *db.PrismaClient, id int) (*db.DockModel, error) {
+	dock, err := client.Dock.FindUnique(
+		db.Dock.ID.Equals(id),
+	).With(
+		db.Dock.Bike.Fetch(),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "dock %d not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, occupied := dock.Bike(); occupied {
+		return nil, status.Errorf(codes.FailedPrecondition, "dock %d is occupied", id)
+	}
+	return dock, nil
+}
+
+/*
+	curl -X POST http://localhost:8080/v1/stations \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{
+	        "name": "Central Station",
+	        "address": "1 Main Street",
+	        "latitude": 52.5251,
+	        "longitude": 13.3694,
+	        "capacity": 12
+	      }'
+*/
+func (server *StationServer) CreateStation(ctx context.Context, req *CreateStationRequest) (*Station, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if err := validCoordinates(req.Latitude, req.Longitude); err != nil {
+		return nil, err
+	}
+	if err := validCapacity(req.Capacity); err != nil {
+		return nil, err
+	}
+	station, err := server.PrismaClient.Station.CreateOne(
+		db.Station.Name.Set(req.Name),
+		db.Station.Latitude.Set(req.Latitude),
+		db.Station.Longitude.Set(req.Longitude),
+		db.Station.Address.Set(req.Address),
+		db.Station.Geohash.Set(Geohash(req.Latitude, req.Longitude, stationGeohashPrecision)),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the docks need the id the database gave the station, so they are
+	// added in a second transaction and the station goes again without them
+	if txs := addDocks(server.PrismaClient, station.ID, 1, int(req.Capacity)); len(txs) > 0 {
+		if err := server.PrismaClient.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+			log.Printf("Could not add docks to station %d: %v", station.ID, err)
+			if _, err := server.PrismaClient.Station.FindUnique(db.Station.ID.Equals(station.ID)).Delete().Exec(ctx); err != nil {
+				log.Printf("Could not delete station %d without docks: %v", station.ID, err)
+			}
+			return nil, status.Error(codes.Internal, "could not create docks")
+		}
+	}
+	return server.GetStation(ctx, &GetStationRequest{Id: int32(station.ID)})
+}
+
+/*
+	curl -X GET http://localhost:8080/v1/stations/1 \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *StationServer) GetStation(ctx context.Context, req *GetStationRequest) (*Station, error) {
+	station, err := server.findStation(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return toStation(station, true), nil
+}
+
+/*
+	curl -X PUT http://localhost:8080/v1/stations/1 \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{
+	        "name": "Central Station North",
+	        "capacity": 16
+	      }'
+*/
+func (server *StationServer) UpdateStation(ctx context.Context, req *UpdateStationRequest) (*Station, error) {
+	station, err := server.findStation(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	var params []db.StationSetParam
+	if req.Name != "" {
+		params = append(params, db.Station.Name.Set(req.Name))
+	}
+	if req.Address != "" {
+		params = append(params, db.Station.Address.Set(req.Address))
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return nil, status.Error(codes.InvalidArgument, "latitude and longitude have to be set together")
+	}
+	if req.Latitude != nil {
+		if err := validCoordinates(*req.Latitude, *req.Longitude); err != nil {
+			return nil, err
+		}
+		params = append(params,
+			db.Station.Latitude.Set(*req.Latitude),
+			db.Station.Longitude.Set(*req.Longitude),
+			db.Station.Geohash.Set(Geohash(*req.Latitude, *req.Longitude, stationGeohashPrecision)),
+		)
+	}
+
+	var txs []db.PrismaTransaction
+	if req.Capacity != nil {
+		if err := validCapacity(*req.Capacity); err != nil {
+			return nil, err
+		}
+		docks := station.Docks()
+		capacity := int(*req.Capacity)
+		if capacity < len(docks) {
+			for _, dock := range docks[capacity:] {
+				if _, occupied := dock.Bike(); occupied {
+					return nil, status.Errorf(codes.FailedPrecondition, "dock %d still holds a bike", dock.Number)
+				}
+			}
+			txs = append(txs, server.PrismaClient.Dock.FindMany(
+				db.Dock.StationID.Equals(station.ID),
+				db.Dock.Number.Gt(capacity),
+			).Delete().Tx())
+		} else if capacity > len(docks) {
+			last := 0
+			if len(docks) > 0 {
+				last = docks[len(docks)-1].Number
+			}
+			txs = append(txs, addDocks(server.PrismaClient, station.ID, last+1, last+capacity-len(docks))...)
+		}
+	}
+	if len(params) > 0 {
+		txs = append(txs, server.PrismaClient.Station.FindUnique(
+			db.Station.ID.Equals(station.ID),
+		).Update(params...).Tx())
+	}
+	if len(txs) > 0 {
+		if err := server.PrismaClient.Prisma.Transaction(txs...).Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return server.GetStation(ctx, &GetStationRequest{Id: req.Id})
+}
+
+/*
+	curl -X DELETE http://localhost:8080/v1/stations/1 \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *StationServer) DeleteStation(ctx context.Context, req *DeleteStationRequest) (*DeleteStationReply, error) {
+	bikes, err := server.PrismaClient.Bike.FindMany(
+		db.Bike.StationID.Equals(int(req.Id)),
+	).Take(1).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(bikes) > 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "station %d still has bikes", req.Id)
+	}
+	station, err := server.PrismaClient.Station.FindUnique(
+		db.Station.ID.Equals(int(req.Id)),
+	).Delete().Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "station %d not found", req.Id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &DeleteStationReply{
+		Message: fmt.Sprintf("Station %d was deleted!", station.ID),
+	}, nil
+}
+
+/*
+	curl -X GET 'http://localhost:8080/v1/stations?page=1&page_size=20' \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *StationServer) ListStations(ctx context.Context, req *ListStationsRequest) (*ListStationsReply, error) {
+	take, skip := paginate(req.Page, req.PageSize, defaultStationPageSize, maxStationPageSize)
+	stations, err := server.PrismaClient.Station.FindMany().With(
+		stationDetails()...,
+	).OrderBy(
+		db.Station.Name.Order(db.SortOrderAsc),
+		db.Station.ID.Order(db.SortOrderAsc),
+	).Take(take).Skip(skip).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply := &ListStationsReply{}
+	for i := range stations {
+		reply.Stations = append(reply.Stations, toStation(&stations[i], false))
+	}
+	return reply, nil
+}
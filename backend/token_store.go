@@ -0,0 +1,296 @@
+package backend
+
+import (
+	"backend/auth"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"db"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RefreshTokenTTL is how long a refresh token can be exchanged for a new
+// access token. Override with REFRESH_TOKEN_TTL.
+var RefreshTokenTTL = durationFromEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+
+var (
+	ErrRefreshTokenInvalid = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned when an already rotated refresh token
+	// is presented again. The whole token family is revoked when it happens,
+	// since either the legitimate client or an attacker holds a stolen copy.
+	ErrRefreshTokenReused = errors.New("refresh token reuse detected")
+)
+
+// TokenStore persists refresh tokens and revoked access token ids.
+// Refresh tokens are only ever stored as SHA-256 hashes.
+type TokenStore struct {
+	PrismaClient *db.PrismaClient
+}
+
+// StartSession records a login of userID and starts the refresh token
+// family of the new Session. mfa records whether the login passed a second
+// factor, so refreshed access tokens keep that status.
+func (s *TokenStore) StartSession(ctx context.Context, userID int, mfa bool) (string, string, error) {
+	sessionID := newTokenID()
+	_, err := s.PrismaClient.Session.CreateOne(
+		db.Session.ID.Set(sessionID),
+		db.Session.User.Link(db.User.ID.Equals(userID)),
+		db.Session.ExpiresAt.Set(time.Now().Add(RefreshTokenTTL)),
+		db.Session.UserAgent.Set(auth.UserAgent(ctx)),
+		db.Session.IP.Set(auth.ClientIP(ctx)),
+	).Exec(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	token, hash := newRefreshToken()
+	if err := s.insertRefreshToken(ctx, userID, sessionID, hash, mfa); err != nil {
+		return "", "", err
+	}
+	return sessionID, token, nil
+}
+
+// RotateRefreshToken exchanges token for a new refresh token of the same
+// family and returns it together with the exchanged token, which has its
+// User loaded.
+func (s *TokenStore) RotateRefreshToken(ctx context.Context, token string) (string, *db.RefreshTokenModel, error) {
+	stored, err := s.PrismaClient.RefreshToken.FindUnique(
+		db.RefreshToken.TokenHash.Equals(hashToken(token)),
+	).With(
+		db.RefreshToken.User.Fetch(),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return "", nil, ErrRefreshTokenInvalid
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	if _, revoked := stored.RevokedAt(); revoked {
+		if _, rotated := stored.ReplacedBy(); rotated {
+			return "", nil, s.revokeFamily(ctx, stored.FamilyID, ErrRefreshTokenReused)
+		}
+		return "", nil, ErrRefreshTokenInvalid
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		return "", nil, ErrRefreshTokenInvalid
+	}
+
+	next, nextHash := newRefreshToken()
+	// only the first of several concurrent rotations may win
+	result, err := s.PrismaClient.RefreshToken.FindMany(
+		db.RefreshToken.ID.Equals(stored.ID),
+		db.RefreshToken.RevokedAt.IsNull(),
+	).Update(
+		db.RefreshToken.RevokedAt.Set(time.Now()),
+		db.RefreshToken.ReplacedBy.Set(nextHash),
+	).Exec(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	if result.Count == 0 {
+		return "", nil, s.revokeFamily(ctx, stored.FamilyID, ErrRefreshTokenReused)
+	}
+
+	if err := s.insertRefreshToken(ctx, stored.UserID, stored.FamilyID, nextHash, stored.Mfa); err != nil {
+		return "", nil, err
+	}
+	if err := s.touchSession(ctx, stored.UserID, stored.FamilyID); err != nil {
+		return "", nil, err
+	}
+	return next, stored, nil
+}
+
+// RevokeRefreshToken revokes token and every other token of its family.
+func (s *TokenStore) RevokeRefreshToken(ctx context.Context, token string) error {
+	stored, err := s.PrismaClient.RefreshToken.FindUnique(
+		db.RefreshToken.TokenHash.Equals(hashToken(token)),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return ErrRefreshTokenInvalid
+	}
+	if err != nil {
+		return err
+	}
+	return s.revokeFamily(ctx, stored.FamilyID, nil)
+}
+
+// RevokeUserRefreshTokens revokes every session and live refresh token of
+// userID, logging the user out on all devices.
+func (s *TokenStore) RevokeUserRefreshTokens(ctx context.Context, userID int) error {
+	now := time.Now()
+	err := s.PrismaClient.Prisma.Transaction(
+		s.PrismaClient.RefreshToken.FindMany(
+			db.RefreshToken.UserID.Equals(userID),
+			db.RefreshToken.RevokedAt.IsNull(),
+		).Update(
+			db.RefreshToken.RevokedAt.Set(now),
+		).Tx(),
+		s.PrismaClient.Session.FindMany(
+			db.Session.UserID.Equals(userID),
+			db.Session.RevokedAt.IsNull(),
+		).Update(
+			db.Session.RevokedAt.Set(now),
+		).Tx(),
+	).Exec(ctx)
+	return err
+}
+
+// RevokeAccessToken blocks the access token identified by claims until it
+// would have expired anyway.
+func (s *TokenStore) RevokeAccessToken(ctx context.Context, claims *Claims) error {
+	expiresAt := time.Now().Add(AccessTokenTTL)
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+	return s.RevokeTokenID(ctx, claims.ID, expiresAt)
+}
+
+// RevokeTokenID blocks the access token with the given jti until expiresAt.
+func (s *TokenStore) RevokeTokenID(ctx context.Context, jti string, expiresAt time.Time) error {
+	if jti == "" {
+		return nil
+	}
+	_, err := s.PrismaClient.RevokedToken.CreateOne(
+		db.RevokedToken.Jti.Set(jti),
+		db.RevokedToken.ExpiresAt.Set(expiresAt),
+	).Exec(ctx)
+	if _, ok := db.IsErrUniqueConstraint(err); ok {
+		return nil
+	}
+	return err
+}
+
+// Authenticate verifies an access token and checks that it has not been
+// revoked. It is meant to be used as the auth.Authenticator of the server.
+func (s *TokenStore) Authenticate(ctx context.Context, token string) (*auth.Identity, error) {
+	claims, err := VerifyJWT(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	revoked, err := s.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not check token: %v", err)
+	}
+	if revoked {
+		return nil, status.Error(codes.Unauthenticated, "token has been revoked")
+	}
+	// tokens issued before sessions existed carry no sid
+	if claims.SessionID != "" {
+		if err := s.checkSession(ctx, claims.SessionID); err != nil {
+			return nil, err
+		}
+	}
+	return &auth.Identity{
+		Email:     claims.Email,
+		Role:      claims.Role,
+		TokenID:   claims.ID,
+		ExpiresAt: claims.ExpiresAt.Time,
+		MFA:       claims.MFA(),
+		SessionID: claims.SessionID,
+	}, nil
+}
+
+// IsRevoked reports whether the access token with the given jti was revoked.
+func (s *TokenStore) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	if jti == "" {
+		return false, nil
+	}
+	_, err := s.PrismaClient.RevokedToken.FindUnique(
+		db.RevokedToken.Jti.Equals(jti),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// PurgeExpired deletes refresh tokens, sessions, revocation entries and
+// password reset tokens that can no longer be used.
+func (s *TokenStore) PurgeExpired(ctx context.Context) error {
+	now := time.Now()
+	_, err := s.PrismaClient.RefreshToken.FindMany(
+		db.RefreshToken.ExpiresAt.Lt(now),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.PrismaClient.RevokedToken.FindMany(
+		db.RevokedToken.ExpiresAt.Lt(now),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return err
+	}
+	// access tokens of a session that no longer exists are refused too
+	_, err = s.PrismaClient.Session.FindMany(
+		db.Session.Or(
+			db.Session.ExpiresAt.Lt(now),
+			db.Session.RevokedAt.Lt(now),
+		),
+	).Delete().Exec(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = s.PrismaClient.PasswordResetToken.FindMany(
+		db.PasswordResetToken.ExpiresAt.Lt(now),
+	).Delete().Exec(ctx)
+	return err
+}
+
+func (s *TokenStore) insertRefreshToken(ctx context.Context, userID int, familyID string, hash string, mfa bool) error {
+	_, err := s.PrismaClient.RefreshToken.CreateOne(
+		db.RefreshToken.TokenHash.Set(hash),
+		db.RefreshToken.FamilyID.Set(familyID),
+		db.RefreshToken.User.Link(db.User.ID.Equals(userID)),
+		db.RefreshToken.ExpiresAt.Set(time.Now().Add(RefreshTokenTTL)),
+		db.RefreshToken.Mfa.Set(mfa),
+	).Exec(ctx)
+	return err
+}
+
+// revokeFamily revokes all live tokens of familyID and the session they
+// belong to, and returns reason so callers can use it directly in a
+// return statement.
+func (s *TokenStore) revokeFamily(ctx context.Context, familyID string, reason error) error {
+	now := time.Now()
+	err := s.PrismaClient.Prisma.Transaction(
+		s.PrismaClient.RefreshToken.FindMany(
+			db.RefreshToken.FamilyID.Equals(familyID),
+			db.RefreshToken.RevokedAt.IsNull(),
+		).Update(
+			db.RefreshToken.RevokedAt.Set(now),
+		).Tx(),
+		s.PrismaClient.Session.FindMany(
+			db.Session.ID.Equals(familyID),
+			db.Session.RevokedAt.IsNull(),
+		).Update(
+			db.Session.RevokedAt.Set(now),
+		).Tx(),
+	).Exec(ctx)
+	if err != nil {
+		return err
+	}
+	return reason
+}
+
+func newRefreshToken() (string, string) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
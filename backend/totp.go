@@ -0,0 +1,106 @@
+package backend
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP implements RFC 6238 time-based one-time passwords with the
+// parameters every authenticator app supports: HMAC-SHA1, 6 digits and a
+// 30 second period.
+type TOTP struct {
+	Period time.Duration
+	Digits int
+	// Skew is how many periods before and after the current one are
+	// accepted to make up for clock drift.
+	Skew int
+}
+
+func NewTOTP() *TOTP {
+	return &TOTP{
+		Period: 30 * time.Second,
+		Digits: 6,
+		Skew:   1,
+	}
+}
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewTOTPSecret returns a random 160 bit secret, base32 encoded.
+func NewTOTPSecret() string {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return totpEncoding.EncodeToString(b)
+}
+
+// URI returns the otpauth:// URI authenticator apps enroll from.
+func (t *TOTP) URI(secret, issuer, account string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprint(t.Digits))
+	v.Set("period", fmt.Sprint(int(t.Period.Seconds())))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + v.Encode()
+}
+
+// Step returns the time step at now.
+func (t *TOTP) Step(now time.Time) int64 {
+	return now.Unix() / int64(t.Period.Seconds())
+}
+
+// Code returns the code of secret for the given time step.
+func (t *TOTP) Code(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("invalid TOTP secret: %w", err)
+	}
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < t.Digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", t.Digits, value%mod), nil
+}
+
+// Validate checks code against secret at now and returns the matching
+// time step. Steps up to and including lastStep are rejected, so a code
+// can only be used once.
+func (t *TOTP) Validate(secret, code string, now time.Time, lastStep int64) (int64, bool) {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != t.Digits {
+		return 0, false
+	}
+	current := t.Step(now)
+	for i := -t.Skew; i <= t.Skew; i++ {
+		step := current + int64(i)
+		if step <= lastStep {
+			continue
+		}
+		expected, err := t.Code(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,264 @@
+package backend
+
+import (
+	"backend/auth"
+	"context"
+	"db"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type UserServer struct {
+	UnimplementedUserServiceServer
+	PrismaClient *db.PrismaClient
+	// Tokens defaults to a TokenStore backed by PrismaClient when nil.
+	Tokens *TokenStore
+
+	tokensOnce sync.Once
+}
+
+func (server *UserServer) tokens() *TokenStore {
+	server.tokensOnce.Do(func() {
+		if server.Tokens == nil {
+			server.Tokens = &TokenStore{PrismaClient: server.PrismaClient}
+		}
+	})
+	return server.Tokens
+}
+
+// me loads the authenticated caller.
+func (server *UserServer) me(ctx context.Context) (*db.UserModel, error) {
+	email, err := CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := server.PrismaClient.User.FindUnique(
+		db.User.Email.Equals(email),
+	).Exec(ctx)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func toUser(user *db.UserModel) *User {
+	name, _ := user.Name()
+	return &User{
+		Id:            int32(user.ID),
+		Email:         user.Email,
+		Name:          name,
+		Surname:       user.Surname,
+		Age:           int32(user.Age),
+		Role:          user.Role,
+		CreatedAt:     timestamppb.New(user.CreatedAt),
+		UpdatedAt:     timestamppb.New(user.UpdatedAt),
+		EmailVerified: user.EmailVerified,
+	}
+}
+
+func toSession(session *db.SessionModel, current string) *Session {
+	return &Session{
+		Id:         session.ID,
+		UserAgent:  session.UserAgent,
+		Ip:         session.IP,
+		CreatedAt:  timestamppb.New(session.CreatedAt),
+		LastSeenAt: timestamppb.New(session.LastSeenAt),
+		Current:    session.ID == current,
+	}
+}
+
+/*
+	curl -X GET http://localhost:8080/v1/users/me \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *UserServer) GetMe(ctx context.Context, req *GetMeRequest) (*User, error) {
+	user, err := server.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(user), nil
+}
+
+/*
+	curl -X PATCH http://localhost:8080/v1/users/me \
+	  -H 'Content-Type: application/json' \
+	  -H 'Authorization: Bearer $TOKEN' \
+	  -d '{
+	        "surname": "Smith"
+	      }'
+*/
+func (server *UserServer) UpdateMe(ctx context.Context, req *UpdateMeRequest) (*User, error) {
+	if req.User == nil {
+		return nil, status.Error(codes.InvalidArgument, "user is required")
+	}
+	paths := req.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		if req.User.Name != "" {
+			paths = append(paths, "name")
+		}
+		if req.User.Surname != "" {
+			paths = append(paths, "surname")
+		}
+		if req.User.Age != 0 {
+			paths = append(paths, "age")
+		}
+	}
+
+	var params []db.UserSetParam
+	for _, path := range paths {
+		switch path {
+		case "name":
+			params = append(params, db.User.Name.Set(req.User.Name))
+		case "surname":
+			params = append(params, db.User.Surname.Set(req.User.Surname))
+		case "age":
+			if req.User.Age < 0 {
+				return nil, status.Error(codes.InvalidArgument, "age must not be negative")
+			}
+			params = append(params, db.User.Age.Set(int(req.User.Age)))
+		default:
+			return nil, status.Errorf(codes.InvalidArgument, "field %q cannot be updated", path)
+		}
+	}
+
+	user, err := server.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(params) == 0 {
+		return toUser(user), nil
+	}
+	updated, err := server.PrismaClient.User.FindUnique(
+		db.User.ID.Equals(user.ID),
+	).Update(params...).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toUser(updated), nil
+}
+
+/*
+	curl -X DELETE http://localhost:8080/v1/users/me \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *UserServer) DeleteMe(ctx context.Context, req *DeleteMeRequest) (*DeleteMeReply, error) {
+	user, err := server.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ongoing, err := server.PrismaClient.Rental.FindMany(
+		db.Rental.UserID.Equals(user.ID),
+		db.Rental.EndTime.IsNull(),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(ongoing) > 0 {
+		return nil, status.Error(codes.FailedPrecondition, "end your ongoing rentals before deleting the account")
+	}
+	// the reservations go with the account, so give their bikes back first
+	// or they stay RESERVED for good
+	reservations, err := server.PrismaClient.Reservation.FindMany(
+		db.Reservation.UserID.Equals(user.ID),
+		db.Reservation.Status.Equals(ReservationActive),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range reservations {
+		if _, err := endReservation(ctx, server.PrismaClient, &reservations[i], ReservationCancelled); err != nil && status.Code(err) != codes.FailedPrecondition {
+			log.Printf("failed to cancel reservation %d of user %d: %v", reservations[i].ID, user.ID, err)
+			return nil, status.Error(codes.Internal, "could not delete account")
+		}
+	}
+
+	// refresh tokens are removed by the cascading relation
+	deleteRentals := server.PrismaClient.Rental.FindMany(
+		db.Rental.UserID.Equals(user.ID),
+	).Delete().Tx()
+	deleteUser := server.PrismaClient.User.FindUnique(
+		db.User.ID.Equals(user.ID),
+	).Delete().Tx()
+	if err := server.PrismaClient.Prisma.Transaction(deleteRentals, deleteUser).Exec(ctx); err != nil {
+		log.Printf("failed to delete user %d: %v", user.ID, err)
+		return nil, status.Error(codes.Internal, "could not delete account")
+	}
+
+	if id, ok := auth.FromContext(ctx); ok {
+		if err := server.tokens().RevokeTokenID(ctx, id.TokenID, id.ExpiresAt); err != nil {
+			log.Printf("failed to revoke token of deleted user %d: %v", user.ID, err)
+		}
+	}
+	return &DeleteMeReply{
+		Reply: fmt.Sprintf("User %d was deleted!", user.ID),
+	}, nil
+}
+
+/*
+	curl -X GET http://localhost:8080/v1/users/me/sessions \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *UserServer) ListSessions(ctx context.Context, req *ListSessionsRequest) (*ListSessionsReply, error) {
+	user, err := server.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := server.PrismaClient.Session.FindMany(
+		db.Session.UserID.Equals(user.ID),
+		db.Session.RevokedAt.IsNull(),
+		db.Session.ExpiresAt.Gt(time.Now()),
+	).OrderBy(
+		db.Session.LastSeenAt.Order(db.SortOrderDesc),
+	).Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var current string
+	if id, ok := auth.FromContext(ctx); ok {
+		current = id.SessionID
+	}
+	reply := &ListSessionsReply{}
+	for i := range sessions {
+		reply.Sessions = append(reply.Sessions, toSession(&sessions[i], current))
+	}
+	return reply, nil
+}
+
+/*
+	curl -X DELETE http://localhost:8080/v1/users/me/sessions/$SESSION_ID \
+	  -H 'Authorization: Bearer $TOKEN'
+*/
+func (server *UserServer) RevokeSession(ctx context.Context, req *RevokeSessionRequest) (*RevokeSessionReply, error) {
+	user, err := server.me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	session, err := server.PrismaClient.Session.FindUnique(
+		db.Session.ID.Equals(req.Id),
+	).Exec(ctx)
+	// sessions of other users are reported as missing too
+	if errors.Is(err, db.ErrNotFound) || (err == nil && session.UserID != user.ID) {
+		return nil, status.Error(codes.NotFound, "session not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := server.tokens().RevokeSession(ctx, session.ID); err != nil {
+		log.Printf("failed to revoke session %s: %v", session.ID, err)
+		return nil, status.Error(codes.Internal, "could not revoke session")
+	}
+	return &RevokeSessionReply{
+		Reply: "Session revoked",
+	}, nil
+}
@@ -0,0 +1,43 @@
+package main_test
+
+import (
+	"testing"
+
+	"backend"
+	pb "backend"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBikeTransitions(t *testing.T) {
+	assert.True(t, backend.CanTransition(pb.BikeStatus_AVAILABLE, pb.BikeStatus_RENTED))
+	assert.True(t, backend.CanTransition(pb.BikeStatus_RENTED, pb.BikeStatus_AVAILABLE))
+	assert.True(t, backend.CanTransition(pb.BikeStatus_LOST, pb.BikeStatus_MAINTENANCE))
+	assert.True(t, backend.CanTransition(pb.BikeStatus_RETIRED, pb.BikeStatus_RETIRED))
+
+	// a bike has to be checked before it goes back into service
+	assert.False(t, backend.CanTransition(pb.BikeStatus_MAINTENANCE, pb.BikeStatus_RENTED))
+	assert.False(t, backend.CanTransition(pb.BikeStatus_RENTED, pb.BikeStatus_RESERVED))
+	assert.False(t, backend.CanTransition(pb.BikeStatus_RETIRED, pb.BikeStatus_AVAILABLE))
+	assert.False(t, backend.CanTransition(pb.BikeStatus_AVAILABLE, pb.BikeStatus_BIKE_STATUS_UNSPECIFIED))
+}
+
+func TestNormalizeBikeStatus(t *testing.T) {
+	for raw, want := range map[string]pb.BikeStatus{
+		"AVAILABLE":      pb.BikeStatus_AVAILABLE,
+		"available":      pb.BikeStatus_AVAILABLE,
+		"in_service":     pb.BikeStatus_AVAILABLE,
+		"COMPLETED":      pb.BikeStatus_AVAILABLE,
+		"ONGOING":        pb.BikeStatus_RENTED,
+		"Out of service": pb.BikeStatus_MAINTENANCE,
+		"stolen":         pb.BikeStatus_LOST,
+	} {
+		got, known := backend.NormalizeBikeStatus(raw)
+		assert.True(t, known, raw)
+		assert.Equal(t, want, got, raw)
+	}
+
+	got, known := backend.NormalizeBikeStatus("purple")
+	assert.False(t, known)
+	assert.Equal(t, pb.BikeStatus_MAINTENANCE, got)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"testing"
+	"time"
 
 	"backend"
 	pb "backend"
@@ -13,6 +14,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
 
@@ -66,7 +69,7 @@ func TestCreateBikeGRPC(t *testing.T) {
 
 	req := &pb.CreateBikeRequest{
 		Model:  "Test Bike",
-		Status: "available",
+		Status: pb.BikeStatus_AVAILABLE,
 	}
 
 	// Act
@@ -91,7 +94,7 @@ func TestGetBikeGRPC(t *testing.T) {
 	// First, create a bike to retrieve
 	createdBike, err := prismaClient.Bike.CreateOne(
 		db.Bike.Model.Set("Test Bike"),
-		db.Bike.Status.Set("available"),
+		db.Bike.Status.Set(pb.BikeStatus_AVAILABLE.String()),
 	).Exec(ctx)
 	assert.NoError(t, err)
 
@@ -106,7 +109,7 @@ func TestGetBikeGRPC(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, createdBike.ID, int(res.Id))
 	assert.Equal(t, createdBike.Model, res.Model)
-	assert.Equal(t, createdBike.Status, res.Status)
+	assert.Equal(t, createdBike.Status, res.Status.String())
 
 	// Clean up
 	_, err = prismaClient.Bike.FindUnique(
@@ -122,14 +125,14 @@ func TestUpdateBikeGRPC(t *testing.T) {
 	// Create a bike to update
 	createdBike, err := prismaClient.Bike.CreateOne(
 		db.Bike.Model.Set("Old Model"),
-		db.Bike.Status.Set("available"),
+		db.Bike.Status.Set(pb.BikeStatus_AVAILABLE.String()),
 	).Exec(ctx)
 	assert.NoError(t, err)
 
 	req := &pb.UpdateBikeRequest{
 		Id:     int32(createdBike.ID),
 		Model:  "New Model",
-		Status: "in_service",
+		Status: pb.BikeStatus_MAINTENANCE,
 	}
 
 	// Act
@@ -148,6 +151,32 @@ func TestUpdateBikeGRPC(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestUpdateBikeRejectsIllegalTransitionGRPC(t *testing.T) {
+	client, prismaClient, ctx, cleanup := setupBikeService(t)
+	defer cleanup()
+
+	createdBike, err := prismaClient.Bike.CreateOne(
+		db.Bike.Model.Set("Old Model"),
+		db.Bike.Status.Set(pb.BikeStatus_RETIRED.String()),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(createdBike.ID)).Delete().Exec(ctx)
+
+	// Act
+	_, retiredErr := client.UpdateBike(ctx, &pb.UpdateBikeRequest{
+		Id:     int32(createdBike.ID),
+		Status: pb.BikeStatus_AVAILABLE,
+	})
+	_, rentedErr := client.UpdateBike(ctx, &pb.UpdateBikeRequest{
+		Id:     int32(createdBike.ID),
+		Status: pb.BikeStatus_RENTED,
+	})
+
+	// Assert
+	assert.Equal(t, codes.FailedPrecondition, status.Code(retiredErr))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(rentedErr))
+}
+
 func TestDeleteBikeGRPC(t *testing.T) {
 	client, prismaClient, ctx, cleanup := setupBikeService(t)
 	defer cleanup()
@@ -155,7 +184,7 @@ func TestDeleteBikeGRPC(t *testing.T) {
 	// Create a bike to delete
 	createdBike, err := prismaClient.Bike.CreateOne(
 		db.Bike.Model.Set("Test Bike"),
-		db.Bike.Status.Set("available"),
+		db.Bike.Status.Set(pb.BikeStatus_AVAILABLE.String()),
 	).Exec(ctx)
 	assert.NoError(t, err)
 
@@ -186,7 +215,7 @@ func TestListBikesGRPC(t *testing.T) {
 	for _, model := range bikeModels {
 		_, err := prismaClient.Bike.CreateOne(
 			db.Bike.Model.Set(model),
-			db.Bike.Status.Set("available"),
+			db.Bike.Status.Set(pb.BikeStatus_AVAILABLE.String()),
 		).Exec(ctx)
 		assert.NoError(t, err)
 	}
@@ -206,3 +235,82 @@ func TestListBikesGRPC(t *testing.T) {
 	// Clean up
 	assert.NoError(t, err)
 }
+
+func TestParseFilter(t *testing.T) {
+	// Act
+	terms, err := backend.ParseFilter(`status = AVAILABLE AND model:"City \"Pro\"" AND create_time>="2025-01-01T00:00:00Z"`)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []backend.FilterTerm{
+		{Field: "status", Op: "=", Value: "AVAILABLE"},
+		{Field: "model", Op: ":", Value: `City "Pro"`},
+		{Field: "create_time", Op: ">=", Value: "2025-01-01T00:00:00Z"},
+	}, terms)
+	for _, filter := range []string{
+		"status = AVAILABLE OR status = RENTED",
+		"NOT status = RENTED",
+		"(status = AVAILABLE)",
+		`model:"unterminated`,
+		"status =",
+		"status = AVAILABLE AND",
+	} {
+		_, err := backend.ParseFilter(filter)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), filter)
+	}
+}
+
+func TestListBikesValidates(t *testing.T) {
+	server := &backend.BikeServer{}
+	ctx := context.Background()
+
+	for _, req := range []*pb.ListBikesRequest{
+		{Filter: "status = PARKED"},
+		{Filter: "colour = red"},
+		{Filter: "model > A"},
+		{Filter: "station_id = central"},
+		{Filter: "create_time > yesterday"},
+		{OrderBy: "battery desc"},
+		{OrderBy: "model sideways"},
+	} {
+		// Act
+		_, err := server.ListBikes(ctx, req)
+
+		// Assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), req.String())
+	}
+}
+
+func TestListBikesFilterAndOrder(t *testing.T) {
+	client, prismaClient, ctx, cleanup := setupBikeService(t)
+	defer cleanup()
+
+	prefix := fmt.Sprintf("Filtered %d", time.Now().UnixNano())
+	for i, s := range []pb.BikeStatus{pb.BikeStatus_AVAILABLE, pb.BikeStatus_MAINTENANCE, pb.BikeStatus_AVAILABLE} {
+		bike, err := prismaClient.Bike.CreateOne(
+			db.Bike.Model.Set(fmt.Sprintf("%s %c", prefix, 'A'+i)),
+			db.Bike.Status.Set(s.String()),
+		).Exec(ctx)
+		assert.NoError(t, err)
+		defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+	}
+
+	// Act
+	res, err := client.ListBikes(ctx, &pb.ListBikesRequest{
+		PageSize: 1,
+		Filter:   fmt.Sprintf("model:%q AND status = AVAILABLE", prefix),
+		OrderBy:  "model desc",
+	})
+	assert.NoError(t, err)
+	unstationed, unstationedErr := client.ListBikes(ctx, &pb.ListBikesRequest{
+		Filter: fmt.Sprintf("model:%q AND station_id != 1", prefix),
+	})
+
+	// Assert
+	assert.Equal(t, int32(2), res.TotalSize)
+	assert.Len(t, res.Bikes, 1)
+	assert.Equal(t, prefix+" C", res.Bikes[0].Model)
+	// bikes without a station are not at station 1 either
+	assert.NoError(t, unstationedErr)
+	assert.Equal(t, int32(3), unstationed.TotalSize)
+}
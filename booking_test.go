@@ -0,0 +1,217 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func slot(start, end time.Time) *pb.TimeSlot {
+	return &pb.TimeSlot{StartTime: timestamppb.New(start), EndTime: timestamppb.New(end)}
+}
+
+func TestFreeSlots(t *testing.T) {
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+
+	// Act
+	free := backend.FreeSlots(at(0), at(24), []*pb.TimeSlot{
+		slot(at(0), at(2)),
+		slot(at(9), at(13)),
+		slot(at(12), at(14)),
+		slot(at(18), at(24)),
+	})
+
+	// Assert
+	var got [][2]time.Time
+	for _, s := range free {
+		got = append(got, [2]time.Time{s.StartTime.AsTime(), s.EndTime.AsTime()})
+	}
+	assert.Equal(t, [][2]time.Time{{at(2), at(9)}, {at(14), at(18)}}, got)
+	assert.Len(t, backend.FreeSlots(at(0), at(24), nil), 1)
+}
+
+func TestCreateBookingRejectsOverlaps(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("booking-overlap-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 2)
+	defer deleteRiders(prismaClient, prefix)
+	bike := createAvailableBike(t, prismaClient, "Booked Bike")
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+
+	server := &backend.RentalServer{PrismaClient: prismaClient}
+	tomorrow := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	booking, err := server.CreateBooking(riders[0], &pb.CreateBookingRequest{
+		BikeId:    int32(bike.ID),
+		StartTime: timestamppb.New(tomorrow.Add(9 * time.Hour)),
+		EndTime:   timestamppb.New(tomorrow.Add(13 * time.Hour)),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, backend.BookingConfirmed, booking.Status)
+
+	// Act
+	_, overlapErr := server.CreateBooking(riders[1], &pb.CreateBookingRequest{
+		BikeId:    int32(bike.ID),
+		StartTime: timestamppb.New(tomorrow.Add(12 * time.Hour)),
+		EndTime:   timestamppb.New(tomorrow.Add(15 * time.Hour)),
+	})
+	adjacent, adjacentErr := server.CreateBooking(riders[1], &pb.CreateBookingRequest{
+		BikeId:    int32(bike.ID),
+		StartTime: timestamppb.New(tomorrow.Add(13 * time.Hour)),
+		EndTime:   timestamppb.New(tomorrow.Add(15 * time.Hour)),
+	})
+
+	// Assert
+	assert.Equal(t, codes.FailedPrecondition, status.Code(overlapErr))
+	assert.NoError(t, adjacentErr)
+	_, err = server.CancelBooking(riders[0], &pb.CancelBookingRequest{Id: adjacent.Id})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	cancelled, err := server.CancelBooking(riders[0], &pb.CancelBookingRequest{Id: booking.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, backend.BookingCancelled, cancelled.Status)
+	_, err = server.CreateBooking(riders[1], &pb.CreateBookingRequest{
+		BikeId:    int32(bike.ID),
+		StartTime: timestamppb.New(tomorrow.Add(12 * time.Hour)),
+		EndTime:   timestamppb.New(tomorrow.Add(13 * time.Hour)),
+	})
+	assert.NoError(t, err)
+}
+
+func TestCreateRentalRefusesImminentBooking(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("booking-imminent-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 2)
+	defer deleteRiders(prismaClient, prefix)
+	holder, walkUp := riders[0], riders[1]
+	bike := createAvailableBike(t, prismaClient, "Soon Booked Bike")
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+
+	server := &backend.RentalServer{PrismaClient: prismaClient}
+	soon := time.Now().Add(backend.BookingBuffer / 2)
+	_, err = server.CreateBooking(holder, &pb.CreateBookingRequest{
+		BikeId:    int32(bike.ID),
+		StartTime: timestamppb.New(soon),
+		EndTime:   timestamppb.New(soon.Add(2 * time.Hour)),
+	})
+	assert.NoError(t, err)
+
+	// Act
+	_, walkUpErr := server.CreateRental(walkUp, &pb.CreateRentalRequest{BikeId: int32(bike.ID)})
+	_, reserveErr := server.ReserveBike(walkUp, &pb.ReserveBikeRequest{BikeId: int32(bike.ID)})
+	_, holderErr := server.CreateRental(holder, &pb.CreateRentalRequest{BikeId: int32(bike.ID)})
+
+	// Assert
+	assert.Equal(t, codes.FailedPrecondition, status.Code(walkUpErr))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(reserveErr))
+	assert.NoError(t, holderErr)
+}
+
+func TestGetBikeAvailability(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("booking-availability-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 1)
+	defer deleteRiders(prismaClient, prefix)
+	bike := createAvailableBike(t, prismaClient, "Calendar Bike")
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+
+	rentals := &backend.RentalServer{PrismaClient: prismaClient}
+	day := time.Now().Add(48 * time.Hour).Truncate(24 * time.Hour)
+	_, err = rentals.CreateBooking(riders[0], &pb.CreateBookingRequest{
+		BikeId:    int32(bike.ID),
+		StartTime: timestamppb.New(day.Add(9 * time.Hour)),
+		EndTime:   timestamppb.New(day.Add(13 * time.Hour)),
+	})
+	assert.NoError(t, err)
+
+	// Act
+	bikes := &backend.BikeServer{PrismaClient: prismaClient}
+	availability, err := bikes.GetBikeAvailability(riders[0], &pb.GetBikeAvailabilityRequest{
+		BikeId:    int32(bike.ID),
+		StartTime: timestamppb.New(day),
+		EndTime:   timestamppb.New(day.Add(24 * time.Hour)),
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BikeStatus_AVAILABLE, availability.Status)
+	assert.Len(t, availability.Busy, 1)
+	assert.Equal(t, "BOOKING", availability.Busy[0].Kind)
+	assert.Len(t, availability.Free, 2)
+	assert.Equal(t, day.Add(9*time.Hour), availability.Free[0].EndTime.AsTime())
+	assert.Equal(t, day.Add(13*time.Hour), availability.Free[1].StartTime.AsTime())
+	_, err = bikes.GetBikeAvailability(riders[0], &pb.GetBikeAvailabilityRequest{
+		BikeId:    int32(bike.ID),
+		StartTime: timestamppb.New(day),
+		EndTime:   timestamppb.New(day.Add(60 * 24 * time.Hour)),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetBikeAvailabilityCountsRentals(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("booking-rented-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 2)
+	defer deleteRiders(prismaClient, prefix)
+	bike := createAvailableBike(t, prismaClient, "Rented Calendar Bike")
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+	broken := createAvailableBike(t, prismaClient, "Broken Calendar Bike")
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(broken.ID)).Delete().Exec(ctx)
+	_, err = prismaClient.Bike.FindUnique(db.Bike.ID.Equals(broken.ID)).Update(
+		db.Bike.Status.Set(pb.BikeStatus_MAINTENANCE.String()),
+	).Exec(ctx)
+	assert.NoError(t, err)
+
+	rentals := &backend.RentalServer{PrismaClient: prismaClient}
+	_, err = rentals.CreateRental(riders[0], &pb.CreateRentalRequest{BikeId: int32(bike.ID)})
+	assert.NoError(t, err)
+	bikes := &backend.BikeServer{PrismaClient: prismaClient}
+	soon := time.Now().Add(backend.ExpectedRentalLength / 2)
+
+	// Act
+	rented, rentedErr := bikes.GetBikeAvailability(riders[1], &pb.GetBikeAvailabilityRequest{BikeId: int32(bike.ID)})
+	inMaintenance, maintenanceErr := bikes.GetBikeAvailability(riders[1], &pb.GetBikeAvailabilityRequest{BikeId: int32(broken.ID)})
+	_, bookErr := rentals.CreateBooking(riders[1], &pb.CreateBookingRequest{
+		BikeId:    int32(bike.ID),
+		StartTime: timestamppb.New(soon),
+		EndTime:   timestamppb.New(soon.Add(time.Hour)),
+	})
+
+	// Assert
+	assert.NoError(t, rentedErr)
+	assert.Len(t, rented.Busy, 1)
+	assert.Equal(t, "RENTAL", rented.Busy[0].Kind)
+	assert.Len(t, rented.Free, 1)
+	assert.NoError(t, maintenanceErr)
+	assert.Empty(t, inMaintenance.Free)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(bookErr))
+}
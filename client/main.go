@@ -5,12 +5,10 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
-	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func main() {
@@ -31,33 +29,27 @@ func main() {
 
 	token := loginReply.Token
 	fmt.Println("Received JWT token:", token)
-	md := metadata.Pairs("authorization", token)
+	md := metadata.Pairs("authorization", "Bearer "+token)
 	ctx := metadata.NewOutgoingContext(context.Background(), md)
-	protectedReply, err := client.SampleProtected(ctx, &ProtectedRequest{
-		Text: "Hello from client",
-	})
+	me, err := NewUserServiceClient(conn).GetMe(ctx, &GetMeRequest{})
 	if err != nil {
-		log.Fatalf("SampleProtected failed: %v", err)
+		log.Fatalf("GetMe failed: %v", err)
 	}
-	fmt.Println("SampleProtected response:", protectedReply.Result)
+	fmt.Println("Logged in as:", me.Email, me.Role)
+	// creating and editing bikes is up to staff, so a rider rents one of
+	// the available bikes
 	bikeClient := NewBikeServiceClient(conn)
-	bikeReply, err := bikeClient.CreateBike(ctx, &CreateBikeRequest{
-		Model:  "Bike X",
-		Status: "ONGOING",
+	bikesReply, err := bikeClient.ListBikes(ctx, &ListBikesRequest{
+		PageSize: 1,
+		Filter:   "status = AVAILABLE",
 	})
 	if err != nil {
-		log.Fatalf("CreateBike failed: %v", err)
+		log.Fatalf("ListBikes failed: %v", err)
 	}
-	bikeReply, err = bikeClient.GetBike(ctx, &GetBikeRequest{
-		Id: bikeReply.Id,
-	})
-	if err != nil {
-		panic(err)
+	if len(bikesReply.Bikes) == 0 {
+		log.Fatalln("No bike is available")
 	}
-	bikeReply, err = bikeClient.UpdateBike(ctx, &UpdateBikeRequest{
-		Id:     bikeReply.Id,
-		Status: "COMPLETED",
-	})
+	bikeReply := bikesReply.Bikes[0]
 	rentalClient := NewRentalServiceClient(conn)
 
 	rentalReply, err := rentalClient.CreateRental(ctx, &CreateRentalRequest{
@@ -68,27 +60,13 @@ func main() {
 	}
 	fmt.Println(rentalReply)
 
-	rentalUpdateReply, err := rentalClient.UpdateRental(ctx, &UpdateRentalRequest{
-		Id:      rentalReply.Id,
-		BikeId:  rentalReply.BikeId,
-		EndTime: timestamppb.New(time.Now()),
-		Status:  "DONE",
-	})
-	if err != nil {
-		panic(err)
-	}
-	fmt.Println(rentalUpdateReply)
-
-	rentalUpdateReply, err = rentalClient.UpdateRental(ctx, &UpdateRentalRequest{
-		Id:      rentalReply.Id,
-		BikeId:  rentalReply.BikeId,
-		EndTime: timestamppb.New(time.Now()),
-		Status:  "Canceled",
+	endRentalReply, err := rentalClient.EndRental(ctx, &EndRentalRequest{
+		RentalId: rentalReply.Id,
 	})
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println(rentalUpdateReply)
+	fmt.Println(endRentalReply)
 
 	listRentalsReply, err := rentalClient.ListRentals(ctx, &ListRentalsRequest{
 		Page:     1,
@@ -0,0 +1,95 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"backend/auth"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEmailVerificationGRPC(t *testing.T) {
+	client, prismaClient, mails, ctx, cleanup := setupAuthServiceWithOutbox(t)
+	defer cleanup()
+
+	email, password := registerTestUser(t, client, ctx)
+	defer deleteTestUser(t, prismaClient, ctx, email)
+
+	mail := mails.last()
+	assert.NotNil(t, mail)
+	assert.Equal(t, email, mail.To)
+	token := resetTokenPattern.FindString(mail.Body)
+	assert.NotEmpty(t, token)
+
+	// unverified accounts can log in but not rent
+	_, err := client.Login(ctx, &pb.LoginRequest{Email: email, Password: password})
+	assert.NoError(t, err)
+
+	bike, err := prismaClient.Bike.CreateOne(
+		db.Bike.Model.Set("Test Bike"),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+
+	rentals := &backend.RentalServer{PrismaClient: prismaClient}
+	riderCtx := auth.NewContext(ctx, &auth.Identity{Email: email, Role: backend.RoleRider})
+	_, err = rentals.CreateRental(riderCtx, &pb.CreateRentalRequest{BikeId: int32(bike.ID)})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Act
+	_, err = client.VerifyEmail(ctx, &pb.VerifyEmailRequest{Token: token})
+
+	// Assert
+	assert.NoError(t, err)
+	rental, err := rentals.CreateRental(riderCtx, &pb.CreateRentalRequest{BikeId: int32(bike.ID)})
+	assert.NoError(t, err)
+	prismaClient.Rental.FindUnique(db.Rental.ID.Equals(int(rental.Id))).Delete().Exec(ctx)
+
+	_, err = client.VerifyEmail(ctx, &pb.VerifyEmailRequest{Token: token})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestResendVerificationEmail(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	email := fmt.Sprintf("resend-%d@example.com", time.Now().UnixNano())
+	user, err := prismaClient.User.CreateOne(
+		db.User.Email.Set(email),
+		db.User.Password.Set("unused"),
+		db.User.Surname.Set("Doe"),
+		db.User.Age.Set(30),
+		db.User.EmailVerified.Set(false),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.User.FindUnique(db.User.ID.Equals(user.ID)).Delete().Exec(ctx)
+	mails := &outbox{}
+	server := &backend.AuthenticatorServer{PrismaClient: prismaClient, Mailer: mails}
+	userCtx := auth.NewContext(ctx, &auth.Identity{Email: email, Role: backend.RoleRider})
+
+	// Act
+	_, err = server.ResendVerificationEmail(userCtx, &pb.ResendVerificationEmailRequest{})
+	_, againErr := server.ResendVerificationEmail(userCtx, &pb.ResendVerificationEmailRequest{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(againErr))
+	mail := mails.last()
+	assert.NotNil(t, mail)
+	assert.Equal(t, email, mail.To)
+	_, err = server.VerifyEmail(ctx, &pb.VerifyEmailRequest{Token: resetTokenPattern.FindString(mail.Body)})
+	assert.NoError(t, err)
+	_, err = server.ResendVerificationEmail(userCtx, &pb.ResendVerificationEmailRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -3,6 +3,7 @@ module main
 go 1.22.2
 
 require (
+	github.com/golang-jwt/jwt/v5 v5.2.1
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.22.0
 	github.com/stretchr/testify v1.9.0
 	google.golang.org/grpc v1.67.1
@@ -0,0 +1,160 @@
+package main_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend"
+	"backend/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// verifyOnly authenticates tokens without the revocation check, which
+// needs the database.
+func verifyOnly(ctx context.Context, token string) (*auth.Identity, error) {
+	claims, err := backend.VerifyJWT(token)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.Identity{Email: claims.Email, Role: claims.Role, TokenID: claims.ID}, nil
+}
+
+func newTestInterceptor() *auth.Interceptor {
+	return &auth.Interceptor{
+		Authenticate: verifyOnly,
+		Authorize:    backend.AuthorizeIdentity,
+		Public:       map[string]bool{"/authenticator.Auth/Login": true},
+	}
+}
+
+func callUnary(t *testing.T, method string, authorization string) (*auth.Identity, error) {
+	ctx := context.Background()
+	if authorization != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", authorization))
+	}
+	var identity *auth.Identity
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		identity, _ = auth.FromContext(ctx)
+		return nil, nil
+	}
+	_, err := newTestInterceptor().Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	return identity, err
+}
+
+func signClaims(t *testing.T, claims *backend.Claims) string {
+	token, err := backend.Keys().Sign(claims)
+	assert.NoError(t, err)
+	return token
+}
+
+func TestInterceptorSetsIdentity(t *testing.T) {
+	backend.SetKeys(backend.NewEd25519KeySet())
+	token, err := backend.GenerateJWT("rider@example.com", backend.RoleRider)
+	assert.NoError(t, err)
+
+	identity, err := callUnary(t, "/bikerental.BikeService/ListBikes", "Bearer "+token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "rider@example.com", identity.Email)
+	assert.Equal(t, backend.RoleRider, identity.Role)
+	assert.NotEmpty(t, identity.TokenID)
+}
+
+func TestInterceptorRejectsBadTokens(t *testing.T) {
+	backend.SetKeys(backend.NewEd25519KeySet())
+	token, err := backend.GenerateJWT("rider@example.com", backend.RoleRider)
+	assert.NoError(t, err)
+
+	expired := backend.NewClaims("rider@example.com", backend.RoleRider)
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+
+	notYetValid := backend.NewClaims("rider@example.com", backend.RoleRider)
+	notYetValid.NotBefore = jwt.NewNumericDate(time.Now().Add(time.Hour))
+
+	wrongAudience := backend.NewClaims("rider@example.com", backend.RoleRider)
+	wrongAudience.Audience = jwt.ClaimStrings{"another-service"}
+
+	wrongIssuer := backend.NewClaims("rider@example.com", backend.RoleRider)
+	wrongIssuer.Issuer = "someone-else"
+
+	foreign, err := backend.NewEd25519KeySet().Sign(backend.NewClaims("rider@example.com", backend.RoleAdmin))
+	assert.NoError(t, err)
+
+	for name, header := range map[string]string{
+		"missing header": "",
+		"raw token":      token,
+		"basic scheme":   "Basic " + token,
+		"empty bearer":   "Bearer ",
+		"garbage":        "Bearer not-a-jwt",
+		"expired":        "Bearer " + signClaims(t, expired),
+		"not yet valid":  "Bearer " + signClaims(t, notYetValid),
+		"wrong audience": "Bearer " + signClaims(t, wrongAudience),
+		"wrong issuer":   "Bearer " + signClaims(t, wrongIssuer),
+		"foreign key":    "Bearer " + foreign,
+	} {
+		_, err := callUnary(t, "/bikerental.BikeService/ListBikes", header)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), name)
+	}
+}
+
+func TestInterceptorPermissionDenied(t *testing.T) {
+	backend.SetKeys(backend.NewEd25519KeySet())
+	token, err := backend.GenerateJWT("rider@example.com", backend.RoleRider)
+	assert.NoError(t, err)
+
+	_, err = callUnary(t, "/bikerental.BikeService/DeleteBike", "Bearer "+token)
+
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestInterceptorPublicMethod(t *testing.T) {
+	identity, err := callUnary(t, "/authenticator.Auth/Login", "")
+
+	assert.NoError(t, err)
+	assert.Nil(t, identity)
+}
+
+func TestInterceptorAPIKeyScopes(t *testing.T) {
+	interceptor := &auth.Interceptor{
+		Authenticate: verifyOnly,
+		AuthenticateAPIKey: func(ctx context.Context, key string) (*auth.Identity, error) {
+			if key != "bk_dock" {
+				return nil, status.Error(codes.Unauthenticated, "invalid API key")
+			}
+			return &auth.Identity{Role: backend.RoleOperator, APIKeyID: 1, Scopes: []string{backend.ScopeBikesRead, backend.ScopeBikesWrite}}, nil
+		},
+		Authorize: backend.AuthorizeIdentity,
+	}
+	call := func(method, key string) (*auth.Identity, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", key))
+		var identity *auth.Identity
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			identity, _ = auth.FromContext(ctx)
+			return nil, nil
+		}
+		_, err := interceptor.Unary()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return identity, err
+	}
+
+	identity, err := call("/bikerental.BikeService/UpdateBike", "bk_dock")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, identity.APIKeyID)
+
+	// outside the key's scopes
+	_, err = call("/bikerental.RentalService/ListRentals", "bk_dock")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// keys can never manage keys
+	_, err = call("/apikey.ApiKeyService/CreateApiKey", "bk_dock")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = call("/bikerental.BikeService/ListBikes", "bk_unknown")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
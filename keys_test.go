@@ -0,0 +1,133 @@
+package main_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"backend"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRSAKey(t *testing.T, id string) *backend.SigningKey {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	return &backend.SigningKey{
+		ID:      id,
+		Method:  jwt.SigningMethodRS256,
+		Private: private,
+		Public:  &private.PublicKey,
+	}
+}
+
+func newEd25519Key(t *testing.T, id string) *backend.SigningKey {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	return &backend.SigningKey{
+		ID:      id,
+		Method:  jwt.SigningMethodEdDSA,
+		Private: private,
+		Public:  public,
+	}
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey := newRSAKey(t, "2024-01")
+	newKey := newEd25519Key(t, "2024-02")
+
+	before, err := backend.NewKeySet("2024-01", oldKey, newKey)
+	assert.NoError(t, err)
+	backend.SetKeys(before)
+	oldToken, err := backend.GenerateJWT("rider@example.com", backend.RoleRider)
+	assert.NoError(t, err)
+
+	after, err := backend.NewKeySet("2024-02", oldKey, newKey)
+	assert.NoError(t, err)
+	backend.SetKeys(after)
+	newToken, err := backend.GenerateJWT("rider@example.com", backend.RoleRider)
+	assert.NoError(t, err)
+
+	// tokens signed with the previous key stay valid during the rotation
+	claims, err := backend.VerifyJWT(oldToken)
+	assert.NoError(t, err)
+	assert.Equal(t, "rider@example.com", claims.Email)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(newToken, &backend.Claims{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02", parsed.Header["kid"])
+	assert.Equal(t, "EdDSA", parsed.Method.Alg())
+
+	// once the old key is removed its tokens are rejected
+	retired, err := backend.NewKeySet("2024-02", newKey)
+	assert.NoError(t, err)
+	backend.SetKeys(retired)
+	_, err = backend.VerifyJWT(oldToken)
+	assert.Error(t, err)
+	_, err = backend.VerifyJWT(newToken)
+	assert.NoError(t, err)
+}
+
+func TestKeySetRejectsAlgorithmMismatch(t *testing.T) {
+	key := newRSAKey(t, "rsa")
+	set, err := backend.NewKeySet("rsa", key)
+	assert.NoError(t, err)
+	backend.SetKeys(set)
+
+	// an HS256 token claiming the RSA kid must not be accepted
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, backend.NewClaims("admin@example.com", backend.RoleAdmin))
+	forged.Header["kid"] = "rsa"
+	tokenString, err := forged.SignedString([]byte("guessed"))
+	assert.NoError(t, err)
+
+	_, err = backend.VerifyJWT(tokenString)
+	assert.Error(t, err)
+}
+
+func TestJWKSRoute(t *testing.T) {
+	set, err := backend.NewKeySet("rsa",
+		newRSAKey(t, "rsa"),
+		newEd25519Key(t, "ed"),
+		&backend.SigningKey{ID: "shared", Method: jwt.SigningMethodHS256, Private: []byte("secret"), Public: []byte("secret")},
+	)
+	assert.NoError(t, err)
+
+	gwmux := runtime.NewServeMux()
+	assert.NoError(t, gwmux.HandlePath("GET", "/.well-known/jwks.json", set.ServeJWKS))
+
+	rec := httptest.NewRecorder()
+	gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Len(t, body.Keys, 2)
+	assert.Equal(t, "ed", body.Keys[0]["kid"])
+	assert.Equal(t, "OKP", body.Keys[0]["kty"])
+	assert.Equal(t, "rsa", body.Keys[1]["kid"])
+	assert.Equal(t, "RS256", body.Keys[1]["alg"])
+}
+
+func TestLoadKeySetFromEnvRequiresKeys(t *testing.T) {
+	for _, name := range []string{"JWT_KEYS_DIR", "JWT_PRIVATE_KEY", "JWT_SECRET"} {
+		t.Setenv(name, "")
+	}
+
+	// Act
+	_, err := backend.LoadKeySetFromEnv()
+	t.Setenv("JWT_EPHEMERAL_KEY", "true")
+	ephemeral, ephemeralErr := backend.LoadKeySetFromEnv()
+
+	// Assert
+	assert.ErrorIs(t, err, backend.ErrNoSigningKeys)
+	assert.NoError(t, ephemeralErr)
+	assert.NotNil(t, ephemeral)
+}
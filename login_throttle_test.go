@@ -0,0 +1,166 @@
+package main_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"backend"
+	"backend/auth"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func newTestThrottle() *backend.LoginThrottle {
+	throttle := backend.NewLoginThrottle(backend.NewMemoryAttemptStore())
+	throttle.MaxFailures = 3
+	throttle.MaxFailuresPerIP = 5
+	throttle.BaseDelay = time.Minute
+	throttle.LockoutDuration = time.Hour
+	return throttle
+}
+
+func TestLoginThrottleBackoffAndLockout(t *testing.T) {
+	ctx := context.Background()
+	throttle := newTestThrottle()
+	email := "rider@example.com"
+
+	// a single typo is not punished
+	assert.NoError(t, throttle.Fail(ctx, email, "10.0.0.1"))
+	assert.NoError(t, throttle.Check(ctx, email, "10.0.0.1"))
+
+	// the second failure starts the backoff
+	assert.NoError(t, throttle.Fail(ctx, email, "10.0.0.1"))
+	err := throttle.Check(ctx, email, "10.0.0.2")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "60 seconds")
+
+	// the third locks the account for the lockout duration
+	assert.NoError(t, throttle.Fail(ctx, email, "10.0.0.1"))
+	err = throttle.Check(ctx, email, "10.0.0.2")
+	assert.Contains(t, status.Convert(err).Message(), "3600 seconds")
+
+	// other accounts are not affected
+	assert.NoError(t, throttle.Check(ctx, "other@example.com", "10.0.0.2"))
+
+	assert.NoError(t, throttle.Unlock(ctx, "RIDER@example.com", ""))
+	assert.NoError(t, throttle.Check(ctx, email, "10.0.0.2"))
+}
+
+func TestLoginThrottlePerIP(t *testing.T) {
+	ctx := context.Background()
+	throttle := newTestThrottle()
+	throttle.BaseDelay = 0
+
+	// spraying one password over many accounts trips the IP limit
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com", "e@example.com"} {
+		assert.NoError(t, throttle.Fail(ctx, email, "10.0.0.1"))
+	}
+
+	err := throttle.Check(ctx, "f@example.com", "10.0.0.1")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.NoError(t, throttle.Check(ctx, "f@example.com", "10.0.0.2"))
+
+	// logging into an own account does not reset the IP counter
+	assert.NoError(t, throttle.Succeed(ctx, "f@example.com"))
+	err = throttle.Check(ctx, "f@example.com", "10.0.0.1")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestLoginThrottleDoesNotBackOffSharedIP(t *testing.T) {
+	ctx := context.Background()
+	throttle := newTestThrottle()
+
+	// riders behind one address mistyping their own passwords
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com"} {
+		assert.NoError(t, throttle.Fail(ctx, email, "10.0.0.1"))
+	}
+
+	assert.NoError(t, throttle.Check(ctx, "e@example.com", "10.0.0.1"))
+	assert.NoError(t, throttle.Fail(ctx, "e@example.com", "10.0.0.1"))
+	err := throttle.Check(ctx, "f@example.com", "10.0.0.1")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "3600 seconds")
+}
+
+func TestLoginThrottleForgetsOldFailures(t *testing.T) {
+	ctx := context.Background()
+	store := backend.NewMemoryAttemptStore()
+	now := time.Now()
+
+	_, err := store.RecordFailure(ctx, "email:rider@example.com", now.Add(-2*time.Hour), time.Hour)
+	assert.NoError(t, err)
+	failures, err := store.RecordFailure(ctx, "email:rider@example.com", now, time.Hour)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, failures)
+
+	assert.NoError(t, store.Purge(ctx, now.Add(time.Minute)))
+	attempts, err := store.Get(ctx, "email:rider@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, attempts.Failures)
+}
+
+func TestLoginThrottleRetryAfterHeaderGRPC(t *testing.T) {
+	throttle := newTestThrottle()
+	desc := grpc.ServiceDesc{
+		ServiceName: "test.Throttled",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Login",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				if err := dec(&emptypb.Empty{}); err != nil {
+					return nil, err
+				}
+				return nil, throttle.Check(ctx, "rider@example.com", auth.ClientIP(ctx))
+			},
+		}},
+	}
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer()
+	s.RegisterService(&desc, struct{}{})
+	go s.Serve(lis)
+	defer s.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+		return lis.Dial()
+	}), grpc.WithInsecure())
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, throttle.Fail(ctx, "rider@example.com", ""))
+	}
+
+	// Act
+	var header metadata.MD
+	err = conn.Invoke(ctx, "/test.Throttled/Login", &emptypb.Empty{}, &emptypb.Empty{}, grpc.Header(&header))
+
+	// Assert
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, []string{"3600"}, header.Get("retry-after"))
+}
+
+func TestClientIP(t *testing.T) {
+	direct := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.7"), Port: 4242},
+	})
+	// direct clients cannot pick their address with x-forwarded-for
+	spoofed := metadata.NewIncomingContext(direct, metadata.Pairs("x-forwarded-for", "198.51.100.1"))
+	assert.Equal(t, "203.0.113.7", auth.ClientIP(spoofed))
+
+	gateway := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 4242},
+	})
+	gateway = metadata.NewIncomingContext(gateway, metadata.Pairs("x-forwarded-for", "198.51.100.1, 203.0.113.9"))
+	assert.Equal(t, "203.0.113.9", auth.ClientIP(gateway))
+}
@@ -0,0 +1,51 @@
+package main_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"backend"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMailer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.txt")
+	mailer := &backend.FileMailer{Path: path, From: "BikeRental <no-reply@example.com>"}
+
+	// Act
+	err := mailer.Send(context.Background(), &backend.Mail{
+		To:      "rider@example.com",
+		Subject: "Hello\r\nBcc: attacker@example.com",
+		Body:    "first line\nsecond line",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	mail := string(data)
+	assert.Contains(t, mail, "From: BikeRental <no-reply@example.com>\r\n")
+	assert.Contains(t, mail, "To: rider@example.com\r\n")
+	assert.Contains(t, mail, "Subject: HelloBcc: attacker@example.com\r\n")
+	assert.NotContains(t, mail, "\r\nBcc:")
+	assert.True(t, strings.Contains(mail, "\r\n\r\nfirst line\r\nsecond line\r\n"))
+}
+
+func TestNewMailerFromEnvRequiresConfig(t *testing.T) {
+	t.Setenv("SMTP_ADDR", "")
+	t.Setenv("MAIL_FILE", "")
+
+	// Act
+	_, err := backend.NewMailerFromEnv()
+	t.Setenv("MAIL_FILE", "-")
+	mailer, stdoutErr := backend.NewMailerFromEnv()
+
+	// Assert
+	assert.ErrorIs(t, err, backend.ErrNoMailer)
+	assert.NoError(t, stdoutErr)
+	assert.IsType(t, &backend.FileMailer{}, mailer)
+}
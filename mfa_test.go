@@ -0,0 +1,77 @@
+package main_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"backend/auth"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTwoStepLoginGRPC(t *testing.T) {
+	client, prismaClient, ctx, cleanup := setupAuthService(t)
+	defer cleanup()
+	backend.SetKeys(backend.NewEd25519KeySet())
+
+	email, password := registerTestUser(t, client, ctx)
+	defer deleteTestUser(t, prismaClient, ctx, email)
+
+	authService := &backend.AuthenticatorServer{PrismaClient: prismaClient}
+	userCtx := auth.NewContext(context.Background(), &auth.Identity{Email: email, Role: backend.RoleRider})
+	enrollment, err := authService.EnrollMFA(userCtx, &pb.EnrollMFARequest{})
+	assert.NoError(t, err)
+
+	totp := backend.NewTOTP()
+	now := time.Now()
+	code, err := totp.Code(enrollment.Secret, totp.Step(now)-1)
+	assert.NoError(t, err)
+	confirmed, err := authService.ConfirmMFA(userCtx, &pb.ConfirmMFARequest{Code: code})
+	assert.NoError(t, err)
+	assert.Len(t, confirmed.RecoveryCodes, 10)
+
+	// Act: the password alone only yields a challenge
+	login, err := client.Login(ctx, &pb.LoginRequest{Email: email, Password: password})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, login.MfaRequired)
+	assert.Empty(t, login.Token)
+	assert.NotEmpty(t, login.MfaToken)
+
+	// the code used for confirmation cannot be replayed
+	_, err = client.VerifyMFA(ctx, &pb.VerifyMFARequest{MfaToken: login.MfaToken, Code: code})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	code, err = totp.Code(enrollment.Secret, totp.Step(now))
+	assert.NoError(t, err)
+	verified, err := client.VerifyMFA(ctx, &pb.VerifyMFARequest{MfaToken: login.MfaToken, Code: code})
+	assert.NoError(t, err)
+	claims, err := backend.VerifyJWT(verified.Token)
+	assert.NoError(t, err)
+	assert.True(t, claims.MFA())
+
+	// refreshed tokens keep the second factor
+	refreshed, err := client.Refresh(ctx, &pb.RefreshRequest{RefreshToken: verified.RefreshToken})
+	assert.NoError(t, err)
+	claims, err = backend.VerifyJWT(refreshed.Token)
+	assert.NoError(t, err)
+	assert.True(t, claims.MFA())
+
+	// recovery codes work once
+	recovery := &pb.VerifyMFARequest{MfaToken: login.MfaToken, Code: confirmed.RecoveryCodes[0]}
+	_, err = client.VerifyMFA(ctx, recovery)
+	assert.NoError(t, err)
+	_, err = client.VerifyMFA(ctx, recovery)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	user, err := prismaClient.User.FindUnique(db.User.Email.Equals(email)).Exec(ctx)
+	assert.NoError(t, err)
+	assert.True(t, user.MfaEnabled)
+}
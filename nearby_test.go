@@ -0,0 +1,145 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGeohash(t *testing.T) {
+	assert.Equal(t, "u4pruydqqvj", backend.Geohash(57.64911, 10.40744, 11))
+	assert.Equal(t, "u4pru", backend.Geohash(57.64911, 10.40744, 5))
+	assert.Equal(t, "s00000000", backend.Geohash(0, 0, 9))
+}
+
+func TestDistanceMeters(t *testing.T) {
+	// Berlin to Paris
+	assert.InDelta(t, 878000, backend.DistanceMeters(52.5200, 13.4050, 48.8566, 2.3522), 5000)
+	assert.Equal(t, 0.0, backend.DistanceMeters(52.52, 13.40, 52.52, 13.40))
+}
+
+func TestNearbyCellsCoverRadius(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, radius := range []float64{50, 500, 5000} {
+		for i := 0; i < 200; i++ {
+			lat := random.Float64()*140 - 70
+			lon := random.Float64()*360 - 180
+			cells := backend.NearbyCells(lat, lon, radius)
+			assert.NotEmpty(t, cells)
+
+			// a point just inside the radius, in a random direction
+			bearing := random.Float64() * 360
+			dLat := radius * 0.99 / 111195 * cosDeg(bearing)
+			dLon := radius * 0.99 / 111195 * sinDeg(bearing) / cosDeg(lat)
+			point := backend.Geohash(lat+dLat, wrapLongitude(lon+dLon), 9)
+			found := false
+			for _, cell := range cells {
+				if strings.HasPrefix(point, cell) {
+					found = true
+				}
+			}
+			assert.True(t, found, "%f,%f radius %f", lat, lon, radius)
+		}
+	}
+}
+
+func TestSearchNearbyValidates(t *testing.T) {
+	server := &backend.StationServer{}
+	ctx := context.Background()
+
+	for _, req := range []*pb.SearchNearbyRequest{
+		{Latitude: 95, Longitude: 13.40},
+		{Latitude: 52.52, Longitude: 13.40, RadiusMeters: 50000},
+		{Latitude: 52.52, Longitude: 13.40, MinBattery: 120},
+	} {
+		// Act
+		_, err := server.SearchNearby(ctx, req)
+
+		// Assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
+func TestSearchNearby(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	// somewhere in the Atlantic, away from other tests' stations
+	lat, lon := 30.0+rand.Float64(), -40.0+rand.Float64()
+	prefix := fmt.Sprintf("nearby-test-%d", time.Now().UnixNano())
+	server := &backend.StationServer{PrismaClient: prismaClient}
+	var stations []*pb.Station
+	// roughly 0 m, 300 m and 2 km north
+	for i, dLat := range []float64{0, 0.0027, 0.018} {
+		station, err := server.CreateStation(ctx, &pb.CreateStationRequest{
+			Name:      fmt.Sprintf("%s-%d", prefix, i),
+			Latitude:  lat + dLat,
+			Longitude: lon,
+			Capacity:  2,
+		})
+		assert.NoError(t, err)
+		defer prismaClient.Station.FindUnique(db.Station.ID.Equals(int(station.Id))).Delete().Exec(ctx)
+		stations = append(stations, station)
+	}
+	bikes := &backend.BikeServer{PrismaClient: prismaClient}
+	var ids []int32
+	for i, station := range stations {
+		bike, err := bikes.CreateBike(ctx, &pb.CreateBikeRequest{Model: prefix, Type: "electric", DockId: station.Docks[0].Id})
+		assert.NoError(t, err)
+		defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(int(bike.Id))).Delete().Exec(ctx)
+		battery := int32(90 - 40*i)
+		_, err = bikes.UpdateBike(ctx, &pb.UpdateBikeRequest{Id: bike.Id, BatteryLevel: &battery})
+		assert.NoError(t, err)
+		ids = append(ids, bike.Id)
+	}
+
+	// Act
+	near, err := server.SearchNearby(ctx, &pb.SearchNearbyRequest{Latitude: lat, Longitude: lon, RadiusMeters: 1000})
+	assert.NoError(t, err)
+	charged, err := server.SearchNearby(ctx, &pb.SearchNearbyRequest{Latitude: lat, Longitude: lon, RadiusMeters: 1000, MinBattery: 60})
+	assert.NoError(t, err)
+	standard, err := server.SearchNearby(ctx, &pb.SearchNearbyRequest{Latitude: lat, Longitude: lon, RadiusMeters: 5000, BikeType: "standard"})
+	assert.NoError(t, err)
+	limited, err := server.SearchNearby(ctx, &pb.SearchNearbyRequest{Latitude: lat, Longitude: lon, RadiusMeters: 5000, Limit: 1})
+	assert.NoError(t, err)
+
+	// Assert
+	if assert.Len(t, near.Stations, 2) {
+		assert.Equal(t, stations[0].Id, near.Stations[0].Station.Id)
+		assert.Equal(t, stations[1].Id, near.Stations[1].Station.Id)
+		assert.InDelta(t, 300, near.Stations[1].DistanceMeters, 10)
+	}
+	if assert.Len(t, near.Bikes, 2) {
+		assert.Equal(t, ids[0], near.Bikes[0].BikeId)
+		assert.Equal(t, ids[1], near.Bikes[1].BikeId)
+	}
+	if assert.Len(t, charged.Bikes, 1) {
+		assert.Equal(t, ids[0], charged.Bikes[0].BikeId)
+	}
+	assert.Len(t, standard.Stations, 3)
+	assert.Empty(t, standard.Bikes)
+	if assert.Len(t, limited.Bikes, 1) {
+		assert.Equal(t, ids[0], limited.Bikes[0].BikeId)
+	}
+}
+
+func cosDeg(d float64) float64 { return math.Cos(d * math.Pi / 180) }
+
+func sinDeg(d float64) float64 { return math.Sin(d * math.Pi / 180) }
+
+func wrapLongitude(lon float64) float64 { return math.Mod(lon+540, 360) - 180 }
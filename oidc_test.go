@@ -0,0 +1,261 @@
+package main_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"backend"
+	"db"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+)
+
+const oidcTestClientID = "bikerental-test"
+
+// fakeOIDCProvider is a minimal identity provider. Every authorization
+// request is approved at once for the user in Subject and Email.
+type fakeOIDCProvider struct {
+	*httptest.Server
+	keys *backend.KeySet
+
+	mu            sync.Mutex
+	Subject       string
+	Email         string
+	EmailVerified bool
+	AMR           []string
+	// pending authorizations by code
+	pending map[string]url.Values
+}
+
+func newFakeOIDCProvider(t *testing.T) *fakeOIDCProvider {
+	keys, err := backend.NewKeySet("idp", newRSAKey(t, "idp"))
+	assert.NoError(t, err)
+	p := &fakeOIDCProvider{
+		keys:          keys,
+		Subject:       "idp-subject-1",
+		Email:         "idp-user@example.com",
+		EmailVerified: true,
+		pending:       map[string]url.Values{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		keys.ServeJWKS(w, r, nil)
+	})
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func (p *fakeOIDCProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"issuer":                                p.URL,
+		"authorization_endpoint":                p.URL + "/authorize",
+		"token_endpoint":                        p.URL + "/token",
+		"jwks_uri":                              p.URL + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+	})
+}
+
+func (p *fakeOIDCProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	code := fmt.Sprintf("code-%d", time.Now().UnixNano())
+	p.mu.Lock()
+	p.pending[code] = q
+	p.mu.Unlock()
+	redirect := q.Get("redirect_uri") + "?" + url.Values{
+		"code":  {code},
+		"state": {q.Get("state")},
+	}.Encode()
+	http.Redirect(w, r, redirect, http.StatusFound)
+}
+
+func (p *fakeOIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	p.mu.Lock()
+	auth, ok := p.pending[r.Form.Get("code")]
+	delete(p.pending, r.Form.Get("code"))
+	p.mu.Unlock()
+	if !ok {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+	sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != auth.Get("code_challenge") {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+	now := time.Now()
+	idToken, err := p.keys.Sign(jwt.MapClaims{
+		"iss":            p.URL,
+		"sub":            p.Subject,
+		"aud":            oidcTestClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Minute).Unix(),
+		"nonce":          auth.Get("nonce"),
+		"email":          p.Email,
+		"email_verified": p.EmailVerified,
+		"given_name":     "Ida",
+		"family_name":    "Provider",
+		"amr":            p.AMR,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"access_token": "idp-access-token",
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     idToken,
+	})
+}
+
+func setupOIDCLogin(t *testing.T, provider *fakeOIDCProvider, prismaClient *db.PrismaClient) (*backend.OIDCLogin, *runtime.ServeMux) {
+	login, err := backend.NewOIDCLogin(context.Background(), prismaClient,
+		provider.URL, oidcTestClientID, "secret", "http://bikerental.test/v1/auth/oidc/callback")
+	assert.NoError(t, err)
+	gwmux := runtime.NewServeMux()
+	assert.NoError(t, gwmux.HandlePath("GET", "/v1/auth/oidc/start", login.Start))
+	assert.NoError(t, gwmux.HandlePath("GET", "/v1/auth/oidc/callback", login.Callback))
+	return login, gwmux
+}
+
+// oidcLogin runs the browser side of the flow and returns the callback
+// response.
+func oidcLogin(t *testing.T, gwmux *runtime.ServeMux) *httptest.ResponseRecorder {
+	start := httptest.NewRecorder()
+	gwmux.ServeHTTP(start, httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/start", nil))
+	assert.Equal(t, http.StatusFound, start.Code)
+
+	noRedirects := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	res, err := noRedirects.Get(start.Header().Get("Location"))
+	assert.NoError(t, err)
+	res.Body.Close()
+	callback, err := url.Parse(res.Header.Get("Location"))
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, callback.RequestURI(), nil)
+	for _, cookie := range start.Result().Cookies() {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	gwmux.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestOIDCStartRedirectsToProvider(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+	_, gwmux := setupOIDCLogin(t, provider, nil)
+
+	// Act
+	rec := httptest.NewRecorder()
+	gwmux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/start", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusFound, rec.Code)
+	location, err := url.Parse(rec.Header().Get("Location"))
+	assert.NoError(t, err)
+	assert.Equal(t, provider.URL+"/authorize", location.Scheme+"://"+location.Host+location.Path)
+	q := location.Query()
+	assert.Equal(t, oidcTestClientID, q.Get("client_id"))
+	assert.NotEmpty(t, q.Get("state"))
+	assert.NotEmpty(t, q.Get("nonce"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+
+	cookies := rec.Result().Cookies()
+	assert.Len(t, cookies, 1)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.Equal(t, "/v1/auth/oidc", cookies[0].Path)
+}
+
+func TestOIDCCallbackRejectsForeignState(t *testing.T) {
+	provider := newFakeOIDCProvider(t)
+	_, gwmux := setupOIDCLogin(t, provider, nil)
+	start := httptest.NewRecorder()
+	gwmux.ServeHTTP(start, httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/start", nil))
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/callback?code=x&state=forged", nil)
+	for _, cookie := range start.Result().Cookies() {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	gwmux.ServeHTTP(rec, req)
+	withoutCookie := httptest.NewRecorder()
+	gwmux.ServeHTTP(withoutCookie, httptest.NewRequest(http.MethodGet, "/v1/auth/oidc/callback?code=x&state=forged", nil))
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, http.StatusBadRequest, withoutCookie.Code)
+}
+
+func TestOIDCLoginGRPC(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	provider := newFakeOIDCProvider(t)
+	provider.Subject = fmt.Sprintf("subject-%d", time.Now().UnixNano())
+	provider.Email = fmt.Sprintf("oidc-test-%d@example.com", time.Now().UnixNano())
+	login, gwmux := setupOIDCLogin(t, provider, prismaClient)
+	defer prismaClient.User.FindMany(db.User.Email.Equals(provider.Email)).Delete().Exec(ctx)
+
+	// unknown identities are refused without auto-provisioning
+	rec := oidcLogin(t, gwmux)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	// Act
+	login.AutoProvision = true
+	login.DefaultRole = backend.RoleOperator
+	first := oidcLogin(t, gwmux)
+	second := oidcLogin(t, gwmux)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusOK, second.Code)
+	var reply struct {
+		Token        string `json:"token"`
+		RefreshToken string `json:"refreshToken"`
+	}
+	assert.NoError(t, json.Unmarshal(second.Body.Bytes(), &reply))
+	assert.NotEmpty(t, reply.RefreshToken)
+	claims, err := backend.VerifyJWT(reply.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, provider.Email, claims.Email)
+	assert.Equal(t, backend.RoleOperator, claims.Role)
+	assert.False(t, claims.MFA())
+
+	user, err := prismaClient.User.FindUnique(db.User.Email.Equals(provider.Email)).Exec(ctx)
+	assert.NoError(t, err)
+	assert.True(t, user.EmailVerified)
+	identities, err := prismaClient.ExternalIdentity.FindMany(
+		db.ExternalIdentity.UserID.Equals(user.ID),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, identities, 1)
+
+	// disabled accounts are refused, not an error of the server
+	_, err = prismaClient.User.FindUnique(db.User.ID.Equals(user.ID)).Update(db.User.DisabledAt.Set(time.Now())).Exec(ctx)
+	assert.NoError(t, err)
+	disabled := oidcLogin(t, gwmux)
+	assert.Equal(t, http.StatusForbidden, disabled.Code)
+}
@@ -0,0 +1,123 @@
+package main_test
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// outbox records mail instead of sending it.
+type outbox struct {
+	mu   sync.Mutex
+	sent []*backend.Mail
+}
+
+func (o *outbox) Send(ctx context.Context, mail *backend.Mail) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.sent = append(o.sent, mail)
+	return nil
+}
+
+func (o *outbox) last() *backend.Mail {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.sent) == 0 {
+		return nil
+	}
+	return o.sent[len(o.sent)-1]
+}
+
+var resetTokenPattern = regexp.MustCompile(`(?m)^[A-Za-z0-9_-]{43}$`)
+
+func setupAuthServiceWithOutbox(t *testing.T) (pb.AuthClient, *db.PrismaClient, *outbox, context.Context, func()) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+
+	mails := &outbox{}
+	authService := &backend.AuthenticatorServer{
+		PrismaClient: prismaClient,
+		Hasher:       backend.NewBcryptHasher(4),
+		Mailer:       mails,
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(authDialer(authService)), grpc.WithInsecure())
+	assert.NoError(t, err)
+
+	cleanup := func() {
+		conn.Close()
+		prismaClient.Disconnect()
+	}
+	return pb.NewAuthClient(conn), prismaClient, mails, ctx, cleanup
+}
+
+func TestPasswordResetGRPC(t *testing.T) {
+	client, prismaClient, mails, ctx, cleanup := setupAuthServiceWithOutbox(t)
+	defer cleanup()
+
+	email, password := registerTestUser(t, client, ctx)
+	defer deleteTestUser(t, prismaClient, ctx, email)
+
+	login, err := client.Login(ctx, &pb.LoginRequest{Email: email, Password: password})
+	assert.NoError(t, err)
+
+	_, err = client.RequestPasswordReset(ctx, &pb.RequestPasswordResetRequest{Email: email})
+	assert.NoError(t, err)
+	// the mail is sent after the reply
+	assert.Eventually(t, func() bool { return mails.last() != nil }, time.Second, 10*time.Millisecond)
+	mail := mails.last()
+	assert.NotNil(t, mail)
+	assert.Equal(t, email, mail.To)
+	token := resetTokenPattern.FindString(mail.Body)
+	assert.NotEmpty(t, token)
+
+	// Act
+	_, err = client.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{
+		Token:       token,
+		NewPassword: "penny-farthing-1885",
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	_, err = client.Login(ctx, &pb.LoginRequest{Email: email, Password: password})
+	assert.Error(t, err)
+	_, err = client.Login(ctx, &pb.LoginRequest{Email: email, Password: "penny-farthing-1885"})
+	assert.NoError(t, err)
+
+	// sessions started with the old password are gone
+	_, err = client.Refresh(ctx, &pb.RefreshRequest{RefreshToken: login.RefreshToken})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// the token is single-use
+	_, err = client.ConfirmPasswordReset(ctx, &pb.ConfirmPasswordResetRequest{
+		Token:       token,
+		NewPassword: "boneshaker-velocipede",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestRequestPasswordResetUnknownEmailGRPC(t *testing.T) {
+	client, _, mails, ctx, cleanup := setupAuthServiceWithOutbox(t)
+	defer cleanup()
+
+	// Act
+	reply, err := client.RequestPasswordReset(ctx, &pb.RequestPasswordResetRequest{Email: "nobody@example.com"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, reply.Reply)
+	assert.Nil(t, mails.last())
+}
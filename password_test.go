@@ -0,0 +1,104 @@
+package main_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"backend"
+	pb "backend"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBcryptHasher(t *testing.T) {
+	hasher := backend.NewBcryptHasher(4)
+
+	hash, err := hasher.Hash("correct horse battery staple")
+	assert.NoError(t, err)
+	assert.NotEqual(t, "correct horse battery staple", hash)
+	assert.False(t, hasher.NeedsRehash(hash))
+
+	ok, err := hasher.Verify(hash, "correct horse battery staple")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hasher.Verify(hash, "wrong password")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// a cost change should trigger an upgrade on next login
+	assert.True(t, backend.NewBcryptHasher(5).NeedsRehash(hash))
+}
+
+func TestArgon2idHasher(t *testing.T) {
+	hasher := backend.NewArgon2idHasher()
+	hasher.Memory = 8 * 1024
+
+	hash, err := hasher.Hash("correct horse battery staple")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=8192,t=1,p=4$"))
+	assert.False(t, hasher.NeedsRehash(hash))
+
+	ok, err := hasher.Verify(hash, "correct horse battery staple")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hasher.Verify(hash, "wrong password")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// hashes from either algorithm verify regardless of the configured one
+	ok, err = backend.NewBcryptHasher(4).Verify(hash, "correct horse battery staple")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, backend.NewBcryptHasher(4).NeedsRehash(hash))
+}
+
+func TestPlaintextPasswordNeedsRehash(t *testing.T) {
+	hasher := backend.NewBcryptHasher(4)
+
+	ok, err := hasher.Verify("password", "password")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, hasher.NeedsRehash("password"))
+
+	ok, err = hasher.Verify("password", "Password")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	policy := backend.NewPasswordPolicy()
+
+	assert.Error(t, policy.Validate("short"))
+	assert.Error(t, policy.Validate("password123"))
+	assert.Error(t, policy.Validate("QWERTYUIOP"))
+	assert.Error(t, policy.Validate(strings.Repeat("a", 73)))
+	assert.NoError(t, policy.Validate("tandem-velodrome-42"))
+}
+
+func TestRegisterIsSafeForConcurrentUse(t *testing.T) {
+	// the defaults are filled in by the first requests, run with -race
+	server := &backend.AuthenticatorServer{}
+	errs := make([]error, 8)
+	var wg sync.WaitGroup
+
+	// Act
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = server.Register(context.Background(), &pb.RegisterRequest{Email: "race@example.com", Password: "short"})
+		}(i)
+	}
+	wg.Wait()
+
+	// Assert
+	for _, err := range errs {
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
@@ -0,0 +1,129 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestPriceRental(t *testing.T) {
+	wednesdayNoon := time.Date(2024, 6, 5, 12, 0, 0, 0, time.UTC)
+	minutes := func(start time.Time, n int) time.Time { return start.Add(time.Duration(n) * time.Minute) }
+
+	assert.Equal(t, 100, backend.PriceRental(backend.DefaultTariff, wednesdayNoon, wednesdayNoon))
+	assert.Equal(t, 115, backend.PriceRental(backend.DefaultTariff, wednesdayNoon, wednesdayNoon.Add(time.Second)))
+	assert.Equal(t, 130, backend.PriceRental(backend.DefaultTariff, wednesdayNoon, wednesdayNoon.Add(61*time.Second)))
+	assert.Equal(t, 100+60*15, backend.PriceRental(backend.DefaultTariff, wednesdayNoon, minutes(wednesdayNoon, 60)))
+
+	tariff := &pb.Tariff{
+		UnlockFeeCents:    50,
+		PerMinuteCents:    10,
+		DailyCapCents:     1000,
+		NightMultiplier:   2,
+		WeekendMultiplier: 1.5,
+	}
+	// two minutes before and two after the night surcharge starts
+	beforeNight := time.Date(2024, 6, 5, 21, 58, 0, 0, time.UTC)
+	assert.Equal(t, 50+10+10+20+20, backend.PriceRental(tariff, beforeNight, minutes(beforeNight, 4)))
+	saturdayNoon := time.Date(2024, 6, 8, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, 50+150, backend.PriceRental(tariff, saturdayNoon, minutes(saturdayNoon, 10)))
+	saturdayNight := time.Date(2024, 6, 8, 23, 0, 0, 0, time.UTC)
+	assert.Equal(t, 50+30, backend.PriceRental(tariff, saturdayNight, minutes(saturdayNight, 1)))
+	// each started 24 hours is capped on its own
+	assert.Equal(t, 50+1000+1000+10, backend.PriceRental(tariff, wednesdayNoon, minutes(wednesdayNoon, 2*24*60+1)))
+	// minutes keep their start second across the hour
+	offHour := time.Date(2024, 6, 5, 21, 58, 30, 0, time.UTC)
+	assert.Equal(t, 50+10+10+20+20, backend.PriceRental(tariff, offHour, minutes(offHour, 4)))
+	uncapped := &pb.Tariff{PerMinuteCents: 10, NightMultiplier: 2}
+	wednesday := time.Date(2024, 6, 5, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 8*60*20+16*60*10, backend.PriceRental(uncapped, wednesday, minutes(wednesday, 24*60)))
+	// ten years cost ten years of daily caps and still price instantly
+	assert.Equal(t, 50+3653*1000, backend.PriceRental(tariff, wednesdayNoon, minutes(wednesdayNoon, 3653*24*60)))
+}
+
+func TestQuoteRentalLimitsMinutes(t *testing.T) {
+	server := &backend.RentalServer{}
+
+	// Act
+	_, err := server.QuoteRental(context.Background(), &pb.QuoteRentalRequest{BikeId: 1, Minutes: math.MaxInt32})
+
+	// Assert
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestQuoteRentalUsesValidTariff(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	bikeType := fmt.Sprintf("quote-test-%d", time.Now().UnixNano())
+	defer prismaClient.Tariff.FindMany(db.Tariff.BikeType.Equals(bikeType)).Delete().Exec(ctx)
+	bike, err := prismaClient.Bike.CreateOne(
+		db.Bike.Model.Set("Cargo Bike"),
+		db.Bike.Type.Set(bikeType),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+
+	pricing := &backend.PricingServer{PrismaClient: prismaClient}
+	current, err := pricing.CreateTariff(ctx, &pb.CreateTariffRequest{
+		BikeType:       bikeType,
+		UnlockFeeCents: 200,
+		PerMinuteCents: 20,
+	})
+	assert.NoError(t, err)
+	upcoming, err := pricing.CreateTariff(ctx, &pb.CreateTariffRequest{
+		BikeType:       bikeType,
+		UnlockFeeCents: 300,
+		PerMinuteCents: 30,
+		ValidFrom:      timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	assert.NoError(t, err)
+	_, err = pricing.CreateTariff(ctx, &pb.CreateTariffRequest{
+		BikeType:  bikeType,
+		ValidFrom: timestamppb.New(time.Now().Add(-time.Hour)),
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, int32(1), current.Version)
+	assert.Equal(t, int32(2), upcoming.Version)
+
+	server := &backend.RentalServer{PrismaClient: prismaClient}
+	monday := time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+
+	// Act
+	now, err := server.QuoteRental(ctx, &pb.QuoteRentalRequest{BikeId: int32(bike.ID), Minutes: 10})
+	assert.NoError(t, err)
+	later, err := server.QuoteRental(ctx, &pb.QuoteRentalRequest{
+		BikeId:    int32(bike.ID),
+		Minutes:   10,
+		StartTime: timestamppb.New(time.Now().Add(2 * time.Hour)),
+	})
+	assert.NoError(t, err)
+	past, err := server.QuoteRental(ctx, &pb.QuoteRentalRequest{
+		BikeId:    int32(bike.ID),
+		Minutes:   10,
+		StartTime: timestamppb.New(monday),
+	})
+
+	// Assert
+	assert.Equal(t, current.Id, now.TariffId)
+	assert.Equal(t, upcoming.Id, later.TariffId)
+	assert.Equal(t, int32(300+10*30), later.CostCents)
+	// before any tariff was valid the default one applies
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), past.TariffId)
+	assert.Equal(t, int32(100+10*15), past.CostCents)
+}
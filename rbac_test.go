@@ -0,0 +1,85 @@
+package main_test
+
+import (
+	"testing"
+
+	"backend"
+	pb "backend"
+	"backend/auth"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorizeFleetMutationsAreStaffOnly(t *testing.T) {
+	for _, method := range []string{
+		"/bikerental.BikeService/CreateBike",
+		"/bikerental.BikeService/UpdateBike",
+		"/bikerental.BikeService/DeleteBike",
+	} {
+		err := backend.Authorize(method, backend.RoleRider)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err), method)
+		assert.NoError(t, backend.Authorize(method, backend.RoleOperator), method)
+		assert.NoError(t, backend.Authorize(method, backend.RoleAdmin), method)
+	}
+}
+
+func TestAuthorizeReadsAreOpenToRiders(t *testing.T) {
+	assert.NoError(t, backend.Authorize("/bikerental.BikeService/ListBikes", backend.RoleRider))
+	assert.NoError(t, backend.Authorize("/bikerental.RentalService/GetRental", backend.RoleRider))
+}
+
+func TestAuthorizeDeniesUnknownMethods(t *testing.T) {
+	err := backend.Authorize("/bikerental.BikeService/Unknown", backend.RoleAdmin)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestAuthorizeIdentityRequiresMFAForStaffOnlyMethods(t *testing.T) {
+	operator := &auth.Identity{Email: "operator@example.com", Role: backend.RoleOperator}
+	err := backend.AuthorizeIdentity("/bikerental.BikeService/DeleteBike", operator)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// methods riders can call do not need a second factor
+	assert.NoError(t, backend.AuthorizeIdentity("/bikerental.BikeService/ListBikes", operator))
+
+	operator.MFA = true
+	assert.NoError(t, backend.AuthorizeIdentity("/bikerental.BikeService/DeleteBike", operator))
+}
+
+func TestAuthorizeAdminServiceIsAdminOnly(t *testing.T) {
+	for _, method := range []string{
+		"/admin.AdminService/ListUsers",
+		"/admin.AdminService/GetUser",
+		"/admin.AdminService/DisableUser",
+		"/admin.AdminService/EnableUser",
+		"/admin.AdminService/SetRole",
+	} {
+		assert.Equal(t, codes.PermissionDenied, status.Code(backend.Authorize(method, backend.RoleRider)), method)
+		assert.Equal(t, codes.PermissionDenied, status.Code(backend.Authorize(method, backend.RoleOperator)), method)
+		assert.NoError(t, backend.Authorize(method, backend.RoleAdmin), method)
+	}
+}
+
+func TestEveryFleetRPCHasPermissionsAndScopes(t *testing.T) {
+	for _, service := range []grpc.ServiceDesc{
+		pb.BikeService_ServiceDesc,
+		pb.RentalService_ServiceDesc,
+		pb.StationService_ServiceDesc,
+	} {
+		for _, method := range service.Methods {
+			fullMethod := "/" + service.ServiceName + "/" + method.MethodName
+			assert.NotEmpty(t, backend.Permissions[fullMethod], fullMethod)
+			assert.NotEmpty(t, backend.MethodScopes[fullMethod], fullMethod)
+		}
+	}
+	for _, scope := range []string{
+		backend.ScopeBikesRead, backend.ScopeBikesWrite,
+		backend.ScopeRentalsRead, backend.ScopeRentalsWrite,
+		backend.ScopeStationsRead, backend.ScopeStationsWrite,
+		backend.ScopeProfileRead,
+	} {
+		assert.True(t, backend.ValidScope(scope), scope)
+	}
+}
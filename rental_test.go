@@ -0,0 +1,315 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"backend/auth"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// createRiders creates n riders with verified email addresses and returns
+// a context authenticated as each of them.
+func createRiders(t *testing.T, prismaClient *db.PrismaClient, prefix string, n int) []context.Context {
+	ctxs := make([]context.Context, n)
+	for i := range ctxs {
+		user, err := prismaClient.User.CreateOne(
+			db.User.Email.Set(fmt.Sprintf("%s-%d@example.com", prefix, i)),
+			db.User.Password.Set("unused"),
+			db.User.Surname.Set("Doe"),
+			db.User.Age.Set(30),
+			db.User.EmailVerified.Set(true),
+		).Exec(context.Background())
+		assert.NoError(t, err)
+		ctxs[i] = auth.NewContext(context.Background(), &auth.Identity{Email: user.Email, Role: backend.RoleRider})
+	}
+	return ctxs
+}
+
+func deleteRiders(prismaClient *db.PrismaClient, prefix string) {
+	ctx := context.Background()
+	prismaClient.Rental.FindMany(
+		db.Rental.User.Where(db.User.Email.StartsWith(prefix)),
+	).Delete().Exec(ctx)
+	prismaClient.User.FindMany(
+		db.User.Email.StartsWith(prefix),
+	).Delete().Exec(ctx)
+}
+
+func TestCreateRentalConcurrently(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("rental-race-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 20)
+	defer deleteRiders(prismaClient, prefix)
+	bike, err := prismaClient.Bike.CreateOne(
+		db.Bike.Model.Set("Contested Bike"),
+		db.Bike.Status.Set(pb.BikeStatus_AVAILABLE.String()),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+
+	server := &backend.RentalServer{PrismaClient: prismaClient}
+
+	// Act
+	errs := make([]error, len(riders))
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i, riderCtx := range riders {
+		wg.Add(1)
+		go func(i int, riderCtx context.Context) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = server.CreateRental(riderCtx, &pb.CreateRentalRequest{BikeId: int32(bike.ID)})
+		}(i, riderCtx)
+	}
+	close(start)
+	wg.Wait()
+
+	// Assert
+	succeeded := 0
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		} else {
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err), err.Error())
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+
+	rentals, err := prismaClient.Rental.FindMany(db.Rental.BikeID.Equals(bike.ID)).Exec(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, rentals, 1)
+	stored, err := prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Exec(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BikeStatus_RENTED.String(), stored.Status)
+}
+
+func TestCreateRentalRejectsUnavailableBike(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("rental-unavailable-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 1)
+	defer deleteRiders(prismaClient, prefix)
+	bike, err := prismaClient.Bike.CreateOne(
+		db.Bike.Model.Set("Broken Bike"),
+		db.Bike.Status.Set(pb.BikeStatus_MAINTENANCE.String()),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+
+	server := &backend.RentalServer{PrismaClient: prismaClient}
+
+	// Act
+	_, err = server.CreateRental(riders[0], &pb.CreateRentalRequest{BikeId: int32(bike.ID)})
+
+	// Assert
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	rentals, err := prismaClient.Rental.FindMany(db.Rental.BikeID.Equals(bike.ID)).Exec(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, rentals)
+}
+
+func TestEndRental(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("rental-end-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 2)
+	defer deleteRiders(prismaClient, prefix)
+	stations := &backend.StationServer{PrismaClient: prismaClient}
+	station, err := stations.CreateStation(ctx, &pb.CreateStationRequest{Name: prefix, Latitude: 52.52, Longitude: 13.40, Capacity: 2})
+	assert.NoError(t, err)
+	defer prismaClient.Station.FindUnique(db.Station.ID.Equals(int(station.Id))).Delete().Exec(ctx)
+	bike, err := prismaClient.Bike.CreateOne(
+		db.Bike.Model.Set("Returned Bike"),
+		db.Bike.Status.Set(pb.BikeStatus_AVAILABLE.String()),
+		db.Bike.Station.Link(db.Station.ID.Equals(int(station.Id))),
+		db.Bike.Dock.Link(db.Dock.ID.Equals(int(station.Docks[0].Id))),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+
+	server := &backend.RentalServer{PrismaClient: prismaClient}
+	rental, err := server.CreateRental(riders[0], &pb.CreateRentalRequest{BikeId: int32(bike.ID)})
+	assert.NoError(t, err)
+	assert.Equal(t, station.Id, rental.StartStationId)
+	undocked, err := prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Exec(ctx)
+	assert.NoError(t, err)
+	_, docked := undocked.DockID()
+	assert.False(t, docked)
+
+	// another rider cannot end it
+	_, err = server.EndRental(riders[1], &pb.EndRentalRequest{RentalId: rental.Id})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Act
+	ended, err := server.EndRental(riders[0], &pb.EndRentalRequest{RentalId: rental.Id, ReturnDockId: station.Docks[1].Id})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, backend.RentalCompleted, ended.Status)
+	assert.NotNil(t, ended.EndTime)
+	// unlock fee and the first started minute
+	assert.Equal(t, int32(115), ended.CostCents)
+	assert.Equal(t, station.Id, ended.ReturnStationId)
+	stored, err := prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Exec(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BikeStatus_AVAILABLE.String(), stored.Status)
+	dockID, _ := stored.DockID()
+	assert.Equal(t, int(station.Docks[1].Id), dockID)
+
+	_, err = server.EndRental(riders[0], &pb.EndRentalRequest{RentalId: rental.Id})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestRentalOwnership(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("rental-owner-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 2)
+	defer deleteRiders(prismaClient, prefix)
+	owner, other := riders[0], riders[1]
+	var bikes []db.BikeModel
+	for i := 0; i < 2; i++ {
+		bike, err := prismaClient.Bike.CreateOne(
+			db.Bike.Model.Set("Owned Bike"),
+			db.Bike.Status.Set(pb.BikeStatus_AVAILABLE.String()),
+		).Exec(ctx)
+		assert.NoError(t, err)
+		defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+		bikes = append(bikes, *bike)
+	}
+
+	server := &backend.RentalServer{PrismaClient: prismaClient}
+	finished, err := server.CreateRental(owner, &pb.CreateRentalRequest{BikeId: int32(bikes[0].ID)})
+	assert.NoError(t, err)
+	_, err = server.EndRental(owner, &pb.EndRentalRequest{RentalId: finished.Id})
+	assert.NoError(t, err)
+	ongoing, err := server.CreateRental(owner, &pb.CreateRentalRequest{BikeId: int32(bikes[1].ID)})
+	assert.NoError(t, err)
+
+	// Act
+	_, getErr := server.GetRental(other, &pb.GetRentalRequest{Id: ongoing.Id})
+	_, updateErr := server.UpdateRental(other, &pb.UpdateRentalRequest{Id: ongoing.Id, Status: "COMPLETED"})
+	_, deleteErr := server.DeleteRental(other, &pb.DeleteRentalRequest{Id: ongoing.Id})
+	othersList, listErr := server.ListRentals(other, &pb.ListRentalsRequest{})
+	all, allErr := server.ListMyRentals(owner, &pb.ListMyRentalsRequest{})
+	current, currentErr := server.ListMyRentals(owner, &pb.ListMyRentalsRequest{Status: backend.RentalOngoing})
+	past, pastErr := server.ListMyRentals(owner, &pb.ListMyRentalsRequest{Status: backend.RentalCompleted})
+	_, badErr := server.ListMyRentals(owner, &pb.ListMyRentalsRequest{Status: "lost"})
+
+	// Assert
+	assert.Equal(t, codes.PermissionDenied, status.Code(getErr))
+	assert.Equal(t, codes.PermissionDenied, status.Code(updateErr))
+	assert.Equal(t, codes.PermissionDenied, status.Code(deleteErr))
+	assert.NoError(t, listErr)
+	assert.Empty(t, othersList.Rentals)
+
+	assert.NoError(t, allErr)
+	assert.Len(t, all.Rentals, 2)
+	assert.NoError(t, currentErr)
+	assert.Len(t, current.Rentals, 1)
+	assert.Equal(t, ongoing.Id, current.Rentals[0].Id)
+	assert.NoError(t, pastErr)
+	assert.Len(t, past.Rentals, 1)
+	assert.Equal(t, finished.Id, past.Rentals[0].Id)
+	assert.Equal(t, codes.InvalidArgument, status.Code(badErr))
+}
+
+func TestUpdateRentalOnlyCorrectsCompletedRentals(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("rental-update-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 1)
+	defer deleteRiders(prismaClient, prefix)
+	operator := auth.NewContext(ctx, &auth.Identity{Email: prefix + "-operator@example.com", Role: backend.RoleOperator})
+	bike := createAvailableBike(t, prismaClient, "Corrected Bike")
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+
+	server := &backend.RentalServer{PrismaClient: prismaClient}
+	rental, err := server.CreateRental(riders[0], &pb.CreateRentalRequest{BikeId: int32(bike.ID)})
+	assert.NoError(t, err)
+	_, ownerErr := server.UpdateRental(riders[0], &pb.UpdateRentalRequest{Id: rental.Id, Status: backend.RentalCompleted})
+	_, ongoingErr := server.UpdateRental(operator, &pb.UpdateRentalRequest{Id: rental.Id, Status: backend.RentalCompleted})
+	_, err = server.EndRental(riders[0], &pb.EndRentalRequest{RentalId: rental.Id})
+	assert.NoError(t, err)
+
+	// Act
+	_, reopenErr := server.UpdateRental(operator, &pb.UpdateRentalRequest{Id: rental.Id, Status: backend.RentalOngoing})
+	corrected, err := server.UpdateRental(operator, &pb.UpdateRentalRequest{
+		Id:      rental.Id,
+		EndTime: timestamppb.New(rental.StartTime.AsTime().Add(time.Second)),
+	})
+
+	// Assert
+	assert.Equal(t, codes.PermissionDenied, status.Code(ownerErr))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(ongoingErr))
+	assert.Equal(t, codes.InvalidArgument, status.Code(reopenErr))
+	assert.NoError(t, err)
+	assert.Equal(t, backend.RentalCompleted, corrected.Status)
+	assert.Equal(t, int32(1), corrected.DurationSeconds)
+	assert.Equal(t, int32(115), corrected.CostCents)
+	assert.Equal(t, pb.BikeStatus_AVAILABLE.String(), bikeStatusOf(t, prismaClient, bike.ID))
+}
+
+func TestDeleteRentalKeepsOngoingRentals(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("rental-delete-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 1)
+	defer deleteRiders(prismaClient, prefix)
+	operator := auth.NewContext(ctx, &auth.Identity{Email: prefix + "-operator@example.com", Role: backend.RoleOperator})
+	bike := createAvailableBike(t, prismaClient, "Deleted Rental Bike")
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+
+	server := &backend.RentalServer{PrismaClient: prismaClient}
+	rental, err := server.CreateRental(riders[0], &pb.CreateRentalRequest{BikeId: int32(bike.ID)})
+	assert.NoError(t, err)
+
+	// Act
+	_, ownerErr := server.DeleteRental(riders[0], &pb.DeleteRentalRequest{Id: rental.Id})
+	_, ongoingErr := server.DeleteRental(operator, &pb.DeleteRentalRequest{Id: rental.Id})
+	_, err = server.EndRental(riders[0], &pb.EndRentalRequest{RentalId: rental.Id})
+	assert.NoError(t, err)
+	deleted, deleteErr := server.DeleteRental(operator, &pb.DeleteRentalRequest{Id: rental.Id})
+
+	// Assert
+	assert.Equal(t, codes.PermissionDenied, status.Code(ownerErr))
+	assert.Equal(t, codes.FailedPrecondition, status.Code(ongoingErr))
+	assert.NoError(t, deleteErr)
+	assert.Equal(t, fmt.Sprintf("Rental %d was deleted!", rental.Id), deleted.Message)
+}
@@ -0,0 +1,143 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func createAvailableBike(t *testing.T, prismaClient *db.PrismaClient, model string) *db.BikeModel {
+	bike, err := prismaClient.Bike.CreateOne(
+		db.Bike.Model.Set(model),
+		db.Bike.Status.Set(pb.BikeStatus_AVAILABLE.String()),
+	).Exec(context.Background())
+	assert.NoError(t, err)
+	return bike
+}
+
+func bikeStatusOf(t *testing.T, prismaClient *db.PrismaClient, id int) string {
+	bike, err := prismaClient.Bike.FindUnique(db.Bike.ID.Equals(id)).Exec(context.Background())
+	assert.NoError(t, err)
+	return bike.Status
+}
+
+func TestReservationConvertsIntoRental(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("reservation-convert-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 2)
+	defer deleteRiders(prismaClient, prefix)
+	holder, other := riders[0], riders[1]
+	bike := createAvailableBike(t, prismaClient, "Reserved Bike")
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+
+	server := &backend.RentalServer{PrismaClient: prismaClient}
+	reservation, err := server.ReserveBike(holder, &pb.ReserveBikeRequest{BikeId: int32(bike.ID)})
+	assert.NoError(t, err)
+	assert.Equal(t, backend.ReservationActive, reservation.Status)
+	assert.Equal(t, pb.BikeStatus_RESERVED.String(), bikeStatusOf(t, prismaClient, bike.ID))
+
+	_, err = server.ReserveBike(other, &pb.ReserveBikeRequest{BikeId: int32(bike.ID)})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = server.CreateRental(other, &pb.CreateRentalRequest{BikeId: int32(bike.ID)})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Act
+	rental, err := server.CreateRental(holder, &pb.CreateRentalRequest{BikeId: int32(bike.ID)})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BikeStatus_RENTED.String(), bikeStatusOf(t, prismaClient, bike.ID))
+	converted, err := server.GetReservation(holder, &pb.GetReservationRequest{Id: reservation.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, backend.ReservationConverted, converted.Status)
+	assert.Equal(t, rental.Id, converted.RentalId)
+	_, err = server.CancelReservation(holder, &pb.CancelReservationRequest{Id: reservation.Id})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestCancelReservation(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("reservation-cancel-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 2)
+	defer deleteRiders(prismaClient, prefix)
+	bike := createAvailableBike(t, prismaClient, "Cancelled Bike")
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(ctx)
+
+	server := &backend.RentalServer{PrismaClient: prismaClient}
+	reservation, err := server.ReserveBike(riders[0], &pb.ReserveBikeRequest{BikeId: int32(bike.ID)})
+	assert.NoError(t, err)
+	_, err = server.CancelReservation(riders[1], &pb.CancelReservationRequest{Id: reservation.Id})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// Act
+	cancelled, err := server.CancelReservation(riders[0], &pb.CancelReservationRequest{Id: reservation.Id})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, backend.ReservationCancelled, cancelled.Status)
+	assert.NotNil(t, cancelled.EndedAt)
+	assert.Equal(t, pb.BikeStatus_AVAILABLE.String(), bikeStatusOf(t, prismaClient, bike.ID))
+}
+
+func TestExpireReservations(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	prefix := fmt.Sprintf("reservation-expire-%d", time.Now().UnixNano())
+	riders := createRiders(t, prismaClient, prefix, 2)
+	defer deleteRiders(prismaClient, prefix)
+	lapsedBike := createAvailableBike(t, prismaClient, "Lapsed Bike")
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(lapsedBike.ID)).Delete().Exec(ctx)
+	heldBike := createAvailableBike(t, prismaClient, "Held Bike")
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(heldBike.ID)).Delete().Exec(ctx)
+
+	server := &backend.RentalServer{PrismaClient: prismaClient}
+	lapsed, err := server.ReserveBike(riders[0], &pb.ReserveBikeRequest{BikeId: int32(lapsedBike.ID)})
+	assert.NoError(t, err)
+	held, err := server.ReserveBike(riders[1], &pb.ReserveBikeRequest{BikeId: int32(heldBike.ID)})
+	assert.NoError(t, err)
+	// as if the server had been down while the reservation ran out
+	_, err = prismaClient.Reservation.FindUnique(
+		db.Reservation.ID.Equals(int(lapsed.Id)),
+	).Update(
+		db.Reservation.ExpiresAt.Set(time.Now().Add(-time.Minute)),
+	).Exec(ctx)
+	assert.NoError(t, err)
+
+	// Act
+	expired, err := backend.ExpireReservations(ctx, prismaClient)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, expired, 1)
+	assert.Equal(t, pb.BikeStatus_AVAILABLE.String(), bikeStatusOf(t, prismaClient, lapsedBike.ID))
+	assert.Equal(t, pb.BikeStatus_RESERVED.String(), bikeStatusOf(t, prismaClient, heldBike.ID))
+	got, err := server.GetReservation(riders[0], &pb.GetReservationRequest{Id: lapsed.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, backend.ReservationExpired, got.Status)
+	got, err = server.GetReservation(riders[1], &pb.GetReservationRequest{Id: held.Id})
+	assert.NoError(t, err)
+	assert.Equal(t, backend.ReservationActive, got.Status)
+}
@@ -2,44 +2,122 @@ package main
 
 import (
 	pb "backend"
+	"backend/auth"
 	"context"
 	"db"
-	"fmt"
 	"log"
 	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/grpc/metadata"
 )
 
-func authUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	if info.FullMethod == "/authenticator.Auth/Login" || info.FullMethod == "/authenticator.Auth/Register" {
-		return handler(ctx, req)
+// publicMethods can be called without an access token.
+var publicMethods = map[string]bool{
+	"/authenticator.Auth/Login":                true,
+	"/authenticator.Auth/Register":             true,
+	"/authenticator.Auth/Refresh":              true,
+	"/authenticator.Auth/Logout":               true,
+	"/authenticator.Auth/VerifyEmail":          true,
+	"/authenticator.Auth/VerifyMFA":            true,
+	"/authenticator.Auth/RequestPasswordReset": true,
+	"/authenticator.Auth/ConfirmPasswordReset": true,
+}
+
+func newAuthInterceptor(tokens *pb.TokenStore, apiKeys *pb.APIKeyStore) *auth.Interceptor {
+	return &auth.Interceptor{
+		Authenticate:       tokens.Authenticate,
+		AuthenticateAPIKey: apiKeys.Authenticate,
+		Authorize:          pb.AuthorizeIdentity,
+		Public:             publicMethods,
 	}
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, fmt.Errorf("missing metadata")
+}
+
+// forwardHeaders passes the x-api-key header on to the gRPC server besides
+// the headers the gateway forwards by default.
+func forwardHeaders(key string) (string, bool) {
+	if strings.EqualFold(key, "X-Api-Key") {
+		return "x-api-key", true
 	}
-	token := md["authorization"]
+	return runtime.DefaultHeaderMatcher(key)
+}
 
-	if len(token) == 0 {
-		return nil, fmt.Errorf("missing token")
+// purgeExpiredTokens periodically removes refresh tokens and revocation
+// entries that have expired.
+func purgeExpiredTokens(tokens *pb.TokenStore, interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := tokens.PurgeExpired(context.Background()); err != nil {
+			log.Println("Failed to purge expired tokens:", err)
+		}
 	}
+}
 
-	claims, err := pb.VerifyJWT(token[0])
-	ctx = metadata.AppendToOutgoingContext(ctx, "current_user", claims.Email)
-	if err != nil {
-		return nil, fmt.Errorf("unauthorized: %v", err)
+// purgeLoginAttempts periodically forgets failed logins that no longer
+// block anyone.
+func purgeLoginAttempts(throttle *pb.LoginThrottle, interval time.Duration) {
+	for range time.Tick(interval) {
+		if err := throttle.Purge(context.Background()); err != nil {
+			log.Println("Failed to purge login attempts:", err)
+		}
 	}
-	return handler(ctx, req)
 }
 
-func RegisterServers(server *grpc.Server, client *db.PrismaClient) {
+// expireReservations periodically makes bikes whose reservation lapsed
+// available again, starting right away to catch up on reservations that
+// lapsed while the server was down.
+func expireReservations(client *db.PrismaClient, interval time.Duration) {
+	for {
+		expired, err := pb.ExpireReservations(context.Background(), client)
+		if err != nil {
+			log.Println("Failed to expire reservations:", err)
+		} else if expired > 0 {
+			log.Printf("Expired %d reservations", expired)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// reloadKeys re-reads the JWT keys on SIGHUP and every interval, so new keys
+// can be rolled out without restarting the server.
+func reloadKeys(keys *pb.KeySet, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	ticker := time.NewTicker(interval)
+	for {
+		select {
+		case <-hup:
+		case <-ticker.C:
+		}
+		if err := keys.Reload(); err != nil {
+			log.Println("Failed to reload JWT keys:", err)
+		}
+	}
+}
+
+func RegisterServers(server *grpc.Server, client *db.PrismaClient, tokens *pb.TokenStore, throttle *pb.LoginThrottle, mailer pb.Mailer) {
 	pb.RegisterAuthServer(server, &pb.AuthenticatorServer{
 		PrismaClient: client,
+		Tokens:       tokens,
+		Mailer:       mailer,
+		Throttle:     throttle,
+	})
+	pb.RegisterUserServiceServer(server, &pb.UserServer{
+		PrismaClient: client,
+		Tokens:       tokens,
+	})
+	pb.RegisterAdminServiceServer(server, &pb.AdminServer{
+		PrismaClient: client,
+		Tokens:       tokens,
+	})
+	pb.RegisterApiKeyServiceServer(server, &pb.ApiKeyServer{
+		PrismaClient: client,
 	})
 	pb.RegisterBikeServiceServer(server, &pb.BikeServer{
 		PrismaClient: client,
@@ -47,13 +125,47 @@ func RegisterServers(server *grpc.Server, client *db.PrismaClient) {
 	pb.RegisterRentalServiceServer(server, &pb.RentalServer{
 		PrismaClient: client,
 	})
+	pb.RegisterPricingServiceServer(server, &pb.PricingServer{
+		PrismaClient: client,
+	})
+	pb.RegisterStationServiceServer(server, &pb.StationServer{
+		PrismaClient: client,
+	})
 }
 
-func RegisterHandlers(gwmux *runtime.ServeMux, conn *grpc.ClientConn) {
+// RegisterOIDCHandlers mounts the OpenID Connect login routes.
+func RegisterOIDCHandlers(gwmux *runtime.ServeMux, login *pb.OIDCLogin) {
+	err := gwmux.HandlePath("GET", "/v1/auth/oidc/start", login.Start)
+	if err != nil {
+		log.Fatalln("Failed to register gateway:", err)
+	}
+	err = gwmux.HandlePath("GET", "/v1/auth/oidc/callback", login.Callback)
+	if err != nil {
+		log.Fatalln("Failed to register gateway:", err)
+	}
+}
+
+func RegisterHandlers(gwmux *runtime.ServeMux, conn *grpc.ClientConn, keys *pb.KeySet) {
 	err := pb.RegisterAuthHandler(context.Background(), gwmux, conn)
 	if err != nil {
 		log.Fatalln("Failed to register gateway:", err)
 	}
+	err = gwmux.HandlePath("GET", "/.well-known/jwks.json", keys.ServeJWKS)
+	if err != nil {
+		log.Fatalln("Failed to register gateway:", err)
+	}
+	err = pb.RegisterUserServiceHandler(context.Background(), gwmux, conn)
+	if err != nil {
+		log.Fatalln("Failed to register gateway:", err)
+	}
+	err = pb.RegisterAdminServiceHandler(context.Background(), gwmux, conn)
+	if err != nil {
+		log.Fatalln("Failed to register gateway:", err)
+	}
+	err = pb.RegisterApiKeyServiceHandler(context.Background(), gwmux, conn)
+	if err != nil {
+		log.Fatalln("Failed to register gateway:", err)
+	}
 	err = pb.RegisterBikeServiceHandler(context.Background(), gwmux, conn)
 	if err != nil {
 		log.Fatalln("Failed to register gateway:", err)
@@ -63,6 +175,14 @@ func RegisterHandlers(gwmux *runtime.ServeMux, conn *grpc.ClientConn) {
 	if err != nil {
 		log.Fatalln("Failed to register gateway:", err)
 	}
+	err = pb.RegisterPricingServiceHandler(context.Background(), gwmux, conn)
+	if err != nil {
+		log.Fatalln("Failed to register gateway:", err)
+	}
+	err = pb.RegisterStationServiceHandler(context.Background(), gwmux, conn)
+	if err != nil {
+		log.Fatalln("Failed to register gateway:", err)
+	}
 }
 
 func main() {
@@ -80,10 +200,47 @@ func main() {
 			panic(err)
 		}
 	}()
+	migrated, err := pb.MigrateBikeStatuses(context.Background(), client)
+	if err != nil {
+		log.Fatalln("Failed to migrate bike statuses:", err)
+	}
+	if migrated > 0 {
+		log.Printf("Normalized the status of %d bikes", migrated)
+	}
+	indexed, err := pb.IndexStations(context.Background(), client)
+	if err != nil {
+		log.Fatalln("Failed to index stations:", err)
+	}
+	if indexed > 0 {
+		log.Printf("Computed the geohash of %d stations", indexed)
+	}
+
+	go expireReservations(client, 30*time.Second)
+
+	keys, err := pb.LoadKeySetFromEnv()
+	if err != nil {
+		log.Fatalln("Failed to load JWT keys:", err)
+	}
+	pb.SetKeys(keys)
+	go reloadKeys(keys, 5*time.Minute)
+
+	tokens := &pb.TokenStore{PrismaClient: client}
+	go purgeExpiredTokens(tokens, time.Hour)
+
+	throttle := pb.NewLoginThrottleFromEnv(client)
+	go purgeLoginAttempts(throttle, 10*time.Minute)
+
+	mailer, err := pb.NewMailerFromEnv()
+	if err != nil {
+		log.Fatalln("Failed to set up mail:", err)
+	}
+
+	interceptor := newAuthInterceptor(tokens, &pb.APIKeyStore{PrismaClient: client})
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(authUnaryInterceptor),
+		grpc.UnaryInterceptor(interceptor.Unary()),
+		grpc.StreamInterceptor(interceptor.Stream()),
 	)
-	RegisterServers(grpcServer, client)
+	RegisterServers(grpcServer, client, tokens, throttle, mailer)
 
 	log.Println("Serving gRPC on 0.0.0.0:50051")
 	go func() {
@@ -98,9 +255,20 @@ func main() {
 		log.Fatalln("Failed to dial server:", err)
 	}
 
-	gwmux := runtime.NewServeMux()
+	gwmux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(forwardHeaders),
+	)
 	// Register Greeter
-	RegisterHandlers(gwmux, conn)
+	RegisterHandlers(gwmux, conn, keys)
+
+	oidcLogin, err := pb.NewOIDCLoginFromEnv(context.Background(), client)
+	if err != nil {
+		log.Fatalln("Failed to set up OIDC login:", err)
+	}
+	if oidcLogin != nil {
+		oidcLogin.Tokens = tokens
+		RegisterOIDCHandlers(gwmux, oidcLogin)
+	}
 	gwServer := &http.Server{
 		Addr:    ":8080",
 		Handler: gwmux,
@@ -0,0 +1,130 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"backend/auth"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// sessionDialer serves userService behind an interceptor that checks
+// tokens against the store, sessions included.
+func sessionDialer(userService pb.UserServiceServer, tokens *backend.TokenStore) func(context.Context, string) (net.Conn, error) {
+	lis := bufconn.Listen(bufSize)
+	interceptor := &auth.Interceptor{
+		Authenticate: tokens.Authenticate,
+		Authorize:    backend.AuthorizeIdentity,
+	}
+	s := grpc.NewServer(grpc.UnaryInterceptor(interceptor.Unary()))
+	pb.RegisterUserServiceServer(s, userService)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+	return func(ctx context.Context, s string) (net.Conn, error) {
+		return lis.Dial()
+	}
+}
+
+func TestUserAgent(t *testing.T) {
+	direct := metadata.NewIncomingContext(context.Background(), metadata.Pairs("user-agent", "grpc-go/1.67.1"))
+	assert.Equal(t, "grpc-go/1.67.1", auth.UserAgent(direct))
+
+	gateway := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"user-agent", "grpc-go/1.67.1",
+		"grpcgateway-user-agent", "Mozilla/5.0",
+	))
+	assert.Equal(t, "Mozilla/5.0", auth.UserAgent(gateway))
+	assert.Equal(t, "", auth.UserAgent(context.Background()))
+}
+
+func TestRevokeSessionGRPC(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+	backend.SetKeys(backend.NewEd25519KeySet())
+
+	hasher := backend.NewBcryptHasher(4)
+	hash, err := hasher.Hash("tandem-velodrome-42")
+	assert.NoError(t, err)
+	user, err := prismaClient.User.CreateOne(
+		db.User.Email.Set(fmt.Sprintf("session-test-%d@example.com", time.Now().UnixNano())),
+		db.User.Password.Set(hash),
+		db.User.Surname.Set("Doe"),
+		db.User.Age.Set(30),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.User.FindMany(db.User.ID.Equals(user.ID)).Delete().Exec(ctx)
+
+	tokens := &backend.TokenStore{PrismaClient: prismaClient}
+	authService := &backend.AuthenticatorServer{
+		PrismaClient: prismaClient,
+		Hasher:       hasher,
+		Tokens:       tokens,
+	}
+	login := func(userAgent string) *pb.LoginReply {
+		reply, err := authService.Login(
+			metadata.NewIncomingContext(ctx, metadata.Pairs("user-agent", userAgent)),
+			&pb.LoginRequest{Email: user.Email, Password: "tandem-velodrome-42"},
+		)
+		assert.NoError(t, err)
+		return reply
+	}
+	laptop := login("laptop")
+	phone := login("phone")
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(sessionDialer(&backend.UserServer{PrismaClient: prismaClient, Tokens: tokens}, tokens)),
+		grpc.WithInsecure(),
+	)
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := pb.NewUserServiceClient(conn)
+	laptopCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+laptop.Token)
+	phoneCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+phone.Token)
+
+	listed, err := client.ListSessions(laptopCtx, &pb.ListSessionsRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, listed.Sessions, 2)
+	var phoneSession string
+	for _, session := range listed.Sessions {
+		if session.UserAgent == "phone" {
+			phoneSession = session.Id
+			assert.False(t, session.Current)
+		} else {
+			assert.True(t, session.Current)
+		}
+	}
+
+	// Act
+	_, err = client.RevokeSession(laptopCtx, &pb.RevokeSessionRequest{Id: phoneSession})
+
+	// Assert
+	assert.NoError(t, err)
+	_, err = client.GetMe(phoneCtx, &pb.GetMeRequest{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = authService.Refresh(ctx, &pb.RefreshRequest{RefreshToken: phone.RefreshToken})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.GetMe(laptopCtx, &pb.GetMeRequest{})
+	assert.NoError(t, err)
+
+	// sessions of other users cannot be revoked, or even confirmed to exist
+	_, err = client.RevokeSession(laptopCtx, &pb.RevokeSessionRequest{Id: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
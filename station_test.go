@@ -0,0 +1,88 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestCreateStationValidates(t *testing.T) {
+	server := &backend.StationServer{}
+	ctx := context.Background()
+
+	for _, req := range []*pb.CreateStationRequest{
+		{Latitude: 52.52, Longitude: 13.40, Capacity: 10},
+		{Name: "North Pole", Latitude: 91, Longitude: 0, Capacity: 10},
+		{Name: "Date Line", Latitude: 0, Longitude: -181, Capacity: 10},
+		{Name: "Negative", Latitude: 52.52, Longitude: 13.40, Capacity: -1},
+		{Name: "Huge", Latitude: 52.52, Longitude: 13.40, Capacity: 10000},
+	} {
+		// Act
+		_, err := server.CreateStation(ctx, req)
+
+		// Assert
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), req.Name)
+	}
+}
+
+func TestStationDocks(t *testing.T) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+	defer prismaClient.Disconnect()
+	ctx := context.Background()
+
+	server := &backend.StationServer{PrismaClient: prismaClient}
+	station, err := server.CreateStation(ctx, &pb.CreateStationRequest{
+		Name:      fmt.Sprintf("station-test-%d", time.Now().UnixNano()),
+		Address:   "1 Main Street",
+		Latitude:  52.52,
+		Longitude: 13.40,
+		Capacity:  3,
+	})
+	assert.NoError(t, err)
+	defer prismaClient.Station.FindUnique(db.Station.ID.Equals(int(station.Id))).Delete().Exec(ctx)
+	assert.Len(t, station.Docks, 3)
+	assert.Equal(t, int32(3), station.FreeDocks)
+
+	bikes := &backend.BikeServer{PrismaClient: prismaClient}
+	bike, err := bikes.CreateBike(ctx, &pb.CreateBikeRequest{Model: "Docked Bike", DockId: station.Docks[2].Id})
+	assert.NoError(t, err)
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(int(bike.Id))).Delete().Exec(ctx)
+	assert.Equal(t, station.Id, bike.StationId)
+	_, err = bikes.CreateBike(ctx, &pb.CreateBikeRequest{Model: "Second Bike", DockId: station.Docks[2].Id})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Act
+	_, shrinkErr := server.UpdateStation(ctx, &pb.UpdateStationRequest{Id: station.Id, Capacity: proto.Int32(2)})
+	grown, growErr := server.UpdateStation(ctx, &pb.UpdateStationRequest{Id: station.Id, Name: "Renamed", Capacity: proto.Int32(5)})
+	_, halfMoveErr := server.UpdateStation(ctx, &pb.UpdateStationRequest{Id: station.Id, Latitude: proto.Float64(0)})
+	moved, moveErr := server.UpdateStation(ctx, &pb.UpdateStationRequest{Id: station.Id, Latitude: proto.Float64(0), Longitude: proto.Float64(0)})
+	_, deleteErr := server.DeleteStation(ctx, &pb.DeleteStationRequest{Id: station.Id})
+
+	// Assert
+	assert.Equal(t, codes.FailedPrecondition, status.Code(shrinkErr))
+	assert.NoError(t, growErr)
+	assert.Equal(t, "Renamed", grown.Name)
+	assert.Equal(t, "1 Main Street", grown.Address)
+	assert.Equal(t, int32(5), grown.Capacity)
+	assert.Equal(t, int32(4), grown.FreeDocks)
+	assert.Equal(t, int32(1), grown.AvailableBikes)
+	assert.Equal(t, int32(5), grown.Docks[4].Number)
+	assert.Equal(t, bike.Id, grown.Docks[2].BikeId)
+	assert.Equal(t, codes.InvalidArgument, status.Code(halfMoveErr))
+	assert.NoError(t, moveErr)
+	assert.Equal(t, 0.0, moved.Latitude)
+	assert.Equal(t, 0.0, moved.Longitude)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(deleteErr))
+}
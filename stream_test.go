@@ -0,0 +1,147 @@
+package main_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"backend"
+	"backend/auth"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// whoamiDesc is a server-streaming service used to check that stream
+// handlers see the authenticated identity.
+var whoamiDesc = grpc.ServiceDesc{
+	ServiceName: "test.Whoami",
+	HandlerType: (*interface{})(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Watch",
+		ServerStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			if err := stream.RecvMsg(&emptypb.Empty{}); err != nil {
+				return err
+			}
+			id, ok := auth.FromContext(stream.Context())
+			if !ok {
+				return status.Error(codes.Internal, "identity missing from stream context")
+			}
+			for _, value := range []string{id.Email, id.Role} {
+				if err := stream.SendMsg(wrapperspb.String(value)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}},
+}
+
+func streamDialer(interceptor *auth.Interceptor) func(context.Context, string) (net.Conn, error) {
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer(
+		grpc.StreamInterceptor(interceptor.Stream()),
+	)
+	s.RegisterService(&whoamiDesc, struct{}{})
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+	return func(ctx context.Context, s string) (net.Conn, error) {
+		return lis.Dial()
+	}
+}
+
+func setupStreamService(t *testing.T) (*grpc.ClientConn, context.Context, func()) {
+	interceptor := &auth.Interceptor{
+		Authenticate: verifyOnly,
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(streamDialer(interceptor)), grpc.WithInsecure())
+	assert.NoError(t, err)
+
+	cleanup := func() {
+		conn.Close()
+	}
+	return conn, ctx, cleanup
+}
+
+func watchWhoami(ctx context.Context, conn *grpc.ClientConn) ([]string, error) {
+	stream, err := conn.NewStream(ctx, &whoamiDesc.Streams[0], "/test.Whoami/Watch")
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.SendMsg(&emptypb.Empty{}); err != nil {
+		return nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, err
+	}
+	var values []string
+	for {
+		msg := &wrapperspb.StringValue{}
+		if err := stream.RecvMsg(msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return values, nil
+			}
+			return values, err
+		}
+		values = append(values, msg.Value)
+	}
+}
+
+func TestStreamInterceptorAuthenticatedGRPC(t *testing.T) {
+	conn, ctx, cleanup := setupStreamService(t)
+	defer cleanup()
+
+	backend.SetKeys(backend.NewEd25519KeySet())
+	token, err := backend.GenerateJWT("operator@example.com", backend.RoleOperator)
+	assert.NoError(t, err)
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+
+	// Act
+	values, err := watchWhoami(ctx, conn)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"operator@example.com", backend.RoleOperator}, values)
+}
+
+func TestStreamInterceptorMissingTokenGRPC(t *testing.T) {
+	conn, ctx, cleanup := setupStreamService(t)
+	defer cleanup()
+
+	// Act
+	_, err := watchWhoami(ctx, conn)
+
+	// Assert
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestStreamInterceptorInvalidTokenGRPC(t *testing.T) {
+	conn, ctx, cleanup := setupStreamService(t)
+	defer cleanup()
+
+	backend.SetKeys(backend.NewEd25519KeySet())
+	token, err := backend.GenerateJWT("rider@example.com", backend.RoleRider)
+	assert.NoError(t, err)
+	// raw tokens without the Bearer scheme are rejected
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", token)
+
+	// Act
+	_, err = watchWhoami(ctx, conn)
+
+	// Assert
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,78 @@
+package main_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"backend"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is the SHA1 key of the RFC 6238 test vectors.
+var rfc6238Secret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCodeMatchesRFC6238(t *testing.T) {
+	totp := backend.NewTOTP()
+	for unix, want := range map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	} {
+		code, err := totp.Code(rfc6238Secret, totp.Step(time.Unix(unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, want, code, unix)
+	}
+}
+
+func TestTOTPValidate(t *testing.T) {
+	totp := backend.NewTOTP()
+	now := time.Unix(1111111109, 0)
+	previous, err := totp.Code(rfc6238Secret, totp.Step(now)-1)
+	assert.NoError(t, err)
+
+	// one step of clock drift is tolerated
+	step, ok := totp.Validate(rfc6238Secret, previous, now, 0)
+	assert.True(t, ok)
+	assert.Equal(t, totp.Step(now)-1, step)
+
+	// a code cannot be used twice
+	_, ok = totp.Validate(rfc6238Secret, previous, now, step)
+	assert.False(t, ok)
+
+	old, err := totp.Code(rfc6238Secret, totp.Step(now)-5)
+	assert.NoError(t, err)
+	_, ok = totp.Validate(rfc6238Secret, old, now, 0)
+	assert.False(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	secret := backend.NewTOTPSecret()
+	uri := backend.NewTOTP().URI(secret, "BikeRental", "operator@example.com")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/BikeRental:operator@example.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+	assert.Contains(t, uri, "issuer=BikeRental")
+}
+
+func TestMFAChallengeIsNotAnAccessToken(t *testing.T) {
+	backend.SetKeys(backend.NewEd25519KeySet())
+	challenge, err := backend.GenerateMFAChallenge("operator@example.com", backend.RoleOperator)
+	assert.NoError(t, err)
+
+	_, err = backend.VerifyJWT(challenge)
+	assert.Error(t, err)
+
+	claims, err := backend.VerifyMFAChallenge(challenge)
+	assert.NoError(t, err)
+	assert.False(t, claims.MFA())
+
+	// and access tokens cannot stand in for the challenge
+	token, err := backend.GenerateJWT("operator@example.com", backend.RoleOperator, backend.AMRPassword)
+	assert.NoError(t, err)
+	_, err = backend.VerifyMFAChallenge(token)
+	assert.Error(t, err)
+}
@@ -0,0 +1,183 @@
+package main_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"backend"
+	pb "backend"
+	"backend/auth"
+	"db"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func userDialer(userService pb.UserServiceServer) func(context.Context, string) (net.Conn, error) {
+	lis := bufconn.Listen(bufSize)
+	interceptor := &auth.Interceptor{
+		Authenticate: verifyOnly,
+		Authorize:    backend.AuthorizeIdentity,
+	}
+	s := grpc.NewServer(grpc.UnaryInterceptor(interceptor.Unary()))
+	pb.RegisterUserServiceServer(s, userService)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			panic(err)
+		}
+	}()
+	return func(ctx context.Context, s string) (net.Conn, error) {
+		return lis.Dial()
+	}
+}
+
+// setupUserService creates a fresh rider and returns a context carrying
+// their access token.
+func setupUserService(t *testing.T) (pb.UserServiceClient, *db.PrismaClient, *db.UserModel, context.Context, func()) {
+	prismaClient := db.NewClient()
+	err := prismaClient.Connect()
+	assert.NoError(t, err)
+
+	userService := &backend.UserServer{
+		PrismaClient: prismaClient,
+	}
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(userDialer(userService)), grpc.WithInsecure())
+	assert.NoError(t, err)
+
+	user, err := prismaClient.User.CreateOne(
+		db.User.Email.Set(fmt.Sprintf("user-test-%d@example.com", time.Now().UnixNano())),
+		db.User.Password.Set("unused"),
+		db.User.Surname.Set("Doe"),
+		db.User.Age.Set(30),
+		db.User.Name.Set("John"),
+	).Exec(ctx)
+	assert.NoError(t, err)
+
+	backend.SetKeys(backend.NewEd25519KeySet())
+	token, err := backend.GenerateJWT(user.Email, backend.RoleRider)
+	assert.NoError(t, err)
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+
+	client := pb.NewUserServiceClient(conn)
+
+	cleanup := func() {
+		prismaClient.User.FindMany(
+			db.User.ID.Equals(user.ID),
+		).Delete().Exec(context.Background())
+		conn.Close()
+		prismaClient.Disconnect()
+	}
+
+	return client, prismaClient, user, ctx, cleanup
+}
+
+func TestGetMeGRPC(t *testing.T) {
+	client, _, user, ctx, cleanup := setupUserService(t)
+	defer cleanup()
+
+	// Act
+	me, err := client.GetMe(ctx, &pb.GetMeRequest{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int32(user.ID), me.Id)
+	assert.Equal(t, user.Email, me.Email)
+	assert.Equal(t, "John", me.Name)
+	assert.Equal(t, backend.RoleRider, me.Role)
+}
+
+func TestUpdateMeFieldMaskGRPC(t *testing.T) {
+	client, _, _, ctx, cleanup := setupUserService(t)
+	defer cleanup()
+
+	// Act
+	me, err := client.UpdateMe(ctx, &pb.UpdateMeRequest{
+		User:       &pb.User{Name: "Jane", Surname: "Smith", Age: 0},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"surname"}},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "John", me.Name)
+	assert.Equal(t, "Smith", me.Surname)
+	assert.Equal(t, int32(30), me.Age)
+
+	_, err = client.UpdateMe(ctx, &pb.UpdateMeRequest{
+		User:       &pb.User{Email: "other@example.com"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"email"}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestDeleteMeGRPC(t *testing.T) {
+	client, prismaClient, user, ctx, cleanup := setupUserService(t)
+	defer cleanup()
+
+	bike, err := prismaClient.Bike.CreateOne(
+		db.Bike.Model.Set("Test Bike"),
+		db.Bike.Status.Set("AVAILABLE"),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(context.Background())
+
+	rental, err := prismaClient.Rental.CreateOne(
+		db.Rental.User.Link(db.User.ID.Equals(user.ID)),
+		db.Rental.Bike.Link(db.Bike.ID.Equals(bike.ID)),
+		db.Rental.StartTime.Set(time.Now()),
+		db.Rental.Status.Set("ONGOING"),
+	).Exec(ctx)
+	assert.NoError(t, err)
+
+	// Act: an ongoing rental blocks the deletion
+	_, err = client.DeleteMe(ctx, &pb.DeleteMeRequest{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = prismaClient.Rental.FindUnique(
+		db.Rental.ID.Equals(rental.ID),
+	).Update(db.Rental.EndTime.Set(time.Now())).Exec(ctx)
+	assert.NoError(t, err)
+
+	_, err = client.DeleteMe(ctx, &pb.DeleteMeRequest{})
+
+	// Assert
+	assert.NoError(t, err)
+	_, err = prismaClient.User.FindUnique(db.User.ID.Equals(user.ID)).Exec(ctx)
+	assert.ErrorIs(t, err, db.ErrNotFound)
+}
+
+func TestDeleteMeReleasesReservedBike(t *testing.T) {
+	client, prismaClient, user, ctx, cleanup := setupUserService(t)
+	defer cleanup()
+
+	bike, err := prismaClient.Bike.CreateOne(
+		db.Bike.Model.Set("Reserved Bike"),
+		db.Bike.Status.Set("RESERVED"),
+	).Exec(ctx)
+	assert.NoError(t, err)
+	defer prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Delete().Exec(context.Background())
+	_, err = prismaClient.Reservation.CreateOne(
+		db.Reservation.User.Link(db.User.ID.Equals(user.ID)),
+		db.Reservation.Bike.Link(db.Bike.ID.Equals(bike.ID)),
+		db.Reservation.ExpiresAt.Set(time.Now().Add(10*time.Minute)),
+	).Exec(ctx)
+	assert.NoError(t, err)
+
+	// Act
+	_, err = client.DeleteMe(ctx, &pb.DeleteMeRequest{})
+
+	// Assert
+	assert.NoError(t, err)
+	released, err := prismaClient.Bike.FindUnique(db.Bike.ID.Equals(bike.ID)).Exec(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "AVAILABLE", released.Status)
+}